@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/TheFellow/arch-lint/example/epsilon/bookstore/app/authors"
 	"github.com/TheFellow/arch-lint/example/epsilon/bookstore/app/books"
+	"github.com/TheFellow/arch-lint/example/epsilon/bookstore/app/books/utils"
 )
 
 type api struct {
@@ -15,3 +16,7 @@ func (a api) Book() books.Book {
 func (a api) Author() authors.Author {
 	return authors.Author{}
 }
+
+func (a api) OtherBook() books.Book {
+	return utils.OtherBook
+}
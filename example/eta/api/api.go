@@ -0,0 +1,7 @@
+package api
+
+import "github.com/TheFellow/arch-lint/example/eta/service"
+
+func Search(query string) []string {
+	return service.Search(query)
+}
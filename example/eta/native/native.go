@@ -0,0 +1,11 @@
+package native
+
+import (
+	"strings"
+	"unsafe"
+)
+
+func Split(s string) []string {
+	b := unsafe.Slice(unsafe.StringData(s), len(s))
+	return strings.Fields(string(b))
+}
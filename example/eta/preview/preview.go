@@ -0,0 +1,8 @@
+package preview
+
+import "slices"
+
+func Rank(words []string) []string {
+	slices.Sort(words)
+	return words
+}
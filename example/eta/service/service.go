@@ -0,0 +1,10 @@
+package service
+
+import (
+	"github.com/TheFellow/arch-lint/example/eta/native"
+	"github.com/TheFellow/arch-lint/example/eta/preview"
+)
+
+func Search(query string) []string {
+	return preview.Rank(native.Split(query))
+}
@@ -0,0 +1,7 @@
+package audit
+
+import "github.com/TheFellow/arch-lint/example/internal/oldlog"
+
+func Record(event string) {
+	oldlog.Printf("audit: %s", event)
+}
@@ -0,0 +1,10 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+func Printf(format string, args ...any) {
+	slog.Info(fmt.Sprintf(format, args...))
+}
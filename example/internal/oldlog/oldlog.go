@@ -0,0 +1,10 @@
+// Package oldlog prints unstructured log lines.
+//
+// Deprecated: use the structured logger of example/internal/log.
+package oldlog
+
+import "fmt"
+
+func Printf(format string, args ...any) {
+	fmt.Printf(format+"\n", args...)
+}
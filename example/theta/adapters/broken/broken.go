@@ -0,0 +1,9 @@
+package broken
+
+import "time"
+
+type Frozen struct{}
+
+func (Frozen) Now() string {
+	return time.Time{}.String()
+}
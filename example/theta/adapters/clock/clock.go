@@ -0,0 +1,10 @@
+package clock
+
+import "time"
+
+// System implements ports.Clock without importing the ports
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
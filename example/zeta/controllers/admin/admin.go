@@ -0,0 +1,11 @@
+package admin
+
+import (
+	//arch-lint:ignore clean architecture - controllers without infrastructure -- admin tooling inspects the database directly
+	//arch-lint:ignore clean architecture - layers -- admin tooling inspects the database directly
+	"github.com/TheFellow/arch-lint/example/zeta/infrastructure/db"
+)
+
+type Console struct {
+	Repo db.Repository
+}
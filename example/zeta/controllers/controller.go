@@ -8,3 +8,8 @@ type Controller struct {
 	// Service usecase.Service // should use this
 	Repo db.Repository // and not this
 }
+
+// New leaks the repository a second time, through its signature
+func New(repo db.Repository) Controller {
+	return Controller{Repo: repo}
+}
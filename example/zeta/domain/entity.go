@@ -7,6 +7,8 @@ type Entity struct {
 
 var _ = usecase.Service{}
 
+var Default = Entity{}
+
 func (e Entity) Validate() (bool, error) {
 	return true, nil
 }
@@ -1,3 +1,7 @@
 package db
 
-type Repository struct{}
+import "database/sql"
+
+type Repository struct {
+	DB *sql.DB
+}
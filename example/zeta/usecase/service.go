@@ -1,7 +1,19 @@
 package usecase
 
-import "github.com/TheFellow/arch-lint/example/zeta/infrastructure/db"
+import (
+	"time"
+
+	"github.com/TheFellow/arch-lint/example/zeta/infrastructure/db"
+)
 
 type Service struct {
 	Repo db.Repository
 }
+
+func (s Service) Stamp() time.Time {
+	return time.Now()
+}
+
+func (s Service) Age(stamp time.Time) time.Duration {
+	return time.Now().Sub(stamp)
+}
@@ -3,15 +3,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"slices"
-	"strings"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/TheFellow/arch-lint/pkg/baseline"
 	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/graph"
 	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/report"
 )
 
 func main() {
@@ -21,6 +23,10 @@ func main() {
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Value: "config.yaml", Usage: "Path to config file"},
 			&cli.BoolFlag{Name: "verbose", Aliases: []string{"v"}, Usage: "Enable verbose output"},
+			&cli.StringFlag{Name: "baseline", Aliases: []string{"b"}, Usage: "Path to baseline file (overrides config)"},
+			&cli.StringFlag{Name: "format", Aliases: []string{"f"}, Value: "text", Usage: "Output format: text, json, sarif, junit, checkstyle or template"},
+			&cli.StringFlag{Name: "template", Usage: "Path to a text/template file used by --format template"},
+			&cli.StringFlag{Name: "fail-on", Value: "error", Usage: "Lowest severity that fails the run: error, warning or info"},
 		},
 		Action: func(ctx context.Context, c *cli.Command) error {
 			cfg, err := config.Load(c.String("config"))
@@ -28,8 +34,12 @@ func main() {
 				fmt.Println(err)
 				os.Exit(1)
 			}
+			failOn, err := config.ParseSeverity(c.String("fail-on"))
+			if err != nil {
+				return err
+			}
 
-			violations, err := linter.Run(cfg)
+			result, err := linter.Run(cfg)
 			if c.Bool("verbose") {
 				fmt.Println(linter.Processed.String())
 			}
@@ -37,21 +47,156 @@ func main() {
 				return err
 			}
 
-			if len(violations) > 0 {
-				slices.SortFunc(violations, func(a, b linter.Violation) int {
-					return strings.Compare(a.String(), b.String())
-				})
-				for _, v := range violations {
-					fmt.Println(v)
+			var known *baseline.Baseline
+			if path := baselinePath(c, cfg); path != "" {
+				if known, err = baseline.Load(path); err != nil {
+					return err
 				}
+			}
+
+			r := report.New(cfg, result, known, failOn)
+			if err := writeReport(os.Stdout, c, r); err != nil {
+				return err
+			}
+			if r.Failed() {
 				os.Exit(1)
 			}
-			fmt.Println("✔ arch-lint: no forbidden imports found.")
 			return nil
 		},
+		Commands: []*cli.Command{
+			{
+				Name:  "baseline",
+				Usage: "Record current violations so only new ones fail",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					cfg, err := config.Load(c.String("config"))
+					if err != nil {
+						fmt.Println(err)
+						os.Exit(1)
+					}
+
+					path := baselinePath(c, cfg)
+					if path == "" {
+						return fmt.Errorf("no baseline path: set 'baseline' in the config or pass --baseline")
+					}
+
+					result, err := linter.Run(cfg)
+					if err != nil {
+						return err
+					}
+					b := baseline.New(result.Violations)
+					if err := b.Write(path); err != nil {
+						return err
+					}
+					fmt.Printf("✔ arch-lint: wrote %d violation(s) to %s\n", len(b.Violations), path)
+					return nil
+				},
+			},
+			{
+				Name:  "graph",
+				Usage: "Export the package import graph, highlighting violations",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "format", Aliases: []string{"f"}, Value: "dot", Usage: "Graph format: dot, mermaid or graphml"},
+					&cli.IntFlag{Name: "depth", Usage: "Collapse packages to this many path segments (0 keeps every package)"},
+					&cli.BoolFlag{Name: "group-by-spec", Usage: "Group packages under the first spec selecting them"},
+					&cli.StringSliceFlag{Name: "include", Usage: "Only draw packages matching these glob patterns"},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					cfg, err := config.Load(c.String("config"))
+					if err != nil {
+						fmt.Println(err)
+						os.Exit(1)
+					}
+
+					result, err := linter.Run(cfg)
+					if err != nil {
+						return err
+					}
+					g := graph.New(result)
+					if c.Bool("group-by-spec") {
+						g.GroupBySpec(cfg.Specs)
+					}
+					g = g.Filter(c.StringSlice("include")).Collapse(c.Int("depth"))
+
+					switch format := c.String("format"); format {
+					case "dot":
+						return graph.WriteDOT(os.Stdout, g)
+					case "mermaid":
+						return graph.WriteMermaid(os.Stdout, g)
+					case "graphml":
+						return graph.WriteGraphML(os.Stdout, g)
+					default:
+						return fmt.Errorf("unknown graph format %q", format)
+					}
+				},
+			},
+			{
+				Name:      "explain",
+				Usage:     "Trace why an import is allowed or forbidden",
+				ArgsUsage: "<importer> <imported>",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					if c.Args().Len() != 2 {
+						return fmt.Errorf("explain requires two arguments: <importer> <imported>")
+					}
+					cfg, err := config.Load(c.String("config"))
+					if err != nil {
+						fmt.Println(err)
+						os.Exit(1)
+					}
+
+					importer, imported := c.Args().Get(0), c.Args().Get(1)
+					explanations := linter.Explain(cfg, importer, imported)
+					if len(explanations) == 0 {
+						fmt.Printf("no spec selects package %q\n", importer)
+						return nil
+					}
+					for i, e := range explanations {
+						if i > 0 {
+							fmt.Println()
+						}
+						fmt.Print(e)
+					}
+					return nil
+				},
+			},
+		},
 	}
 
 	if err := app.Run(context.Background(), os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// baselinePath returns the baseline file from the command line or config, if any.
+func baselinePath(c *cli.Command, cfg *config.Config) string {
+	if path := c.String("baseline"); path != "" {
+		return path
+	}
+	return cfg.Baseline
+}
+
+// writeReport renders the report in the requested format.
+func writeReport(w io.Writer, c *cli.Command, r *report.Report) error {
+	switch format := c.String("format"); format {
+	case "text":
+		return report.WriteText(w, r)
+	case "json":
+		return report.WriteJSON(w, r)
+	case "sarif":
+		return report.WriteSARIF(w, r)
+	case "junit":
+		return report.WriteJUnit(w, r)
+	case "checkstyle":
+		return report.WriteCheckstyle(w, r)
+	case "template":
+		if c.String("template") == "" {
+			return fmt.Errorf("--format template requires --template")
+		}
+		tmpl, err := report.ParseTemplate(c.String("template"))
+		if err != nil {
+			return err
+		}
+		return report.WriteTemplate(w, r, tmpl)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
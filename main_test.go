@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -26,9 +29,153 @@ func TestArchLint(t *testing.T) {
 	testutil.Equals(t, got, want)
 }
 
+func TestArchLint_Baseline(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "baseline.yml")
+	out, err := exec.Command("go", "run", ".", "-c", "./example/rules.yml", "-b", path, "baseline").Output()
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, string(out), "✔ arch-lint: wrote 19 violation(s) to "+path+"\n")
+
+	out, err = exec.Command("go", "run", ".", "-c", "./example/rules.yml", "-b", path).Output()
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, string(out), "✔ arch-lint: no forbidden imports found.\n")
+}
+
+func TestArchLint_FailOn(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	rules := `
+specs:
+  - name: no-experimental-imports
+    severity: warning
+    packages:
+      include:
+        - "example/alpha/**"
+      exclude:
+        - "example/alpha/internal/**"
+    rules:
+      forbid:
+        - "example/alpha/experimental"
+
+  - name: clean architecture - controllers without infrastructure
+    severity: info
+    packages:
+      include:
+        - "example/zeta/controllers/**"
+    rules:
+      forbid:
+        - "example/zeta/infrastructure/**"
+`
+	testutil.Equals(t, os.WriteFile(path, []byte(rules), 0o644), nil)
+	violations := `arch-lint: warning: example/zeta/controllers/admin/admin.go:5:2: unused suppression of [clean architecture - layers]
+arch-lint: info: [clean architecture - controllers without infrastructure] package "example/zeta/controllers" imports "example/zeta/infrastructure/db"
+arch-lint: warning: [no-experimental-imports] package "example/alpha" imports "example/alpha/experimental"
+`
+
+	out, err := exec.Command("go", "run", ".", "-c", path).Output()
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, string(out), violations+"✔ arch-lint: no violations at or above severity error.\n")
+
+	out, err = exec.Command("go", "run", ".", "-c", path, "--fail-on", "warning").Output()
+	testutil.ErrorIf(t, err == nil, "got %v, want %v", err, "non-nil")
+	testutil.Equals(t, string(out), violations)
+}
+
+func TestArchLint_Symbols(t *testing.T) {
+	t.Parallel()
+	// Without transitive or label rules, dependencies are loaded only to type-check the packages.
+	// Each use of time.Now is reported.
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	rules := `
+specs:
+  - name: deterministic use cases
+    packages:
+      include:
+        - "example/zeta/usecase/**"
+    symbols:
+      forbid:
+        - "time.Now"
+`
+	testutil.Equals(t, os.WriteFile(path, []byte(rules), 0o644), nil)
+	out, err := exec.Command("go", "run", ".", "-c", path).Output()
+	testutil.ErrorIf(t, err == nil, "got %v, want %v", err, "non-nil")
+	testutil.Equals(t, string(out), `arch-lint: warning: example/zeta/controllers/admin/admin.go:4:2: unused suppression of [clean architecture - controllers without infrastructure]
+arch-lint: warning: example/zeta/controllers/admin/admin.go:5:2: unused suppression of [clean architecture - layers]
+arch-lint: error: [deterministic use cases] package "example/zeta/usecase" uses "time.Now"
+arch-lint: error: [deterministic use cases] package "example/zeta/usecase" uses "time.Now"
+`)
+}
+
+func TestArchLint_Hexagonal(t *testing.T) {
+	t.Parallel()
+	// Adapters implementing a port are accepted even if they do not import it
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	rules := `
+hexagonal:
+  - name: ports and adapters
+    ports:
+      - "example/theta/ports"
+    adapters:
+      - "example/theta/adapters/**"
+`
+	testutil.Equals(t, os.WriteFile(path, []byte(rules), 0o644), nil)
+	out, err := exec.Command("go", "run", ".", "-c", path).Output()
+	testutil.ErrorIf(t, err == nil, "got %v, want %v", err, "non-nil")
+	testutil.Equals(t, string(out), `arch-lint: warning: example/zeta/controllers/admin/admin.go:4:2: unused suppression of [clean architecture - controllers without infrastructure]
+arch-lint: warning: example/zeta/controllers/admin/admin.go:5:2: unused suppression of [clean architecture - layers]
+arch-lint: error: [ports and adapters] package "example/theta/adapters/broken": no exported type implements a port of example/theta/ports
+`)
+}
+
+func TestArchLint_Layers(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	rules := `
+layers:
+  name: clean architecture - layers
+  strict: %t
+  order:
+    - name: controllers
+      packages: ["example/zeta/controllers/**"]
+    - name: usecase
+      packages: ["example/zeta/usecase/**"]
+    - name: infrastructure
+      packages: ["example/zeta/infrastructure/**"]
+    - name: domain
+      packages: ["example/zeta/domain/**"]
+`
+	unused := "arch-lint: warning: example/zeta/controllers/admin/admin.go:4:2: unused suppression of [clean architecture - controllers without infrastructure]\n"
+	upward := `arch-lint: error: [clean architecture - layers] package "example/zeta/domain" imports "example/zeta/usecase": layer "domain" may not import higher layer "usecase"` + "\n"
+	skipped := `arch-lint: error: [clean architecture - layers] package "example/zeta/controllers" imports "example/zeta/infrastructure/db": layer "controllers" may only import the layer directly below, "usecase", not "infrastructure"` + "\n"
+
+	// Skipping a layer is only a violation in strict mode, importing a higher layer always is
+	unusedLayers := "arch-lint: warning: example/zeta/controllers/admin/admin.go:5:2: unused suppression of [clean architecture - layers]\n"
+	for strict, want := range map[bool]string{false: unused + unusedLayers + upward, true: unused + skipped + upward} {
+		testutil.Equals(t, os.WriteFile(path, []byte(fmt.Sprintf(rules, strict)), 0o644), nil)
+		out, err := exec.Command("go", "run", ".", "-c", path).Output()
+		testutil.ErrorIf(t, err == nil, "got %v, want %v", err, "non-nil")
+		testutil.Equals(t, string(out), want)
+	}
+}
+
 var wantOut string = `
-arch-lint: [app package from api only] package "example/beta/bookstore/app/books" imports "example/beta/bookstore/app/authors"
-arch-lint: [app package from api or other features only] package "example/epsilon/bookstore/app/books/utils" imports "example/epsilon/bookstore/app/books"
-arch-lint: [clean architecture - controllers without infrastructure] package "example/zeta/controllers" imports "example/zeta/infrastructure/db"
-arch-lint: [clean architecture - domain independent] package "example/zeta/domain" imports "example/zeta/usecase"
-arch-lint: [no-experimental-imports] package "example/alpha" imports "example/alpha/experimental"`
+arch-lint: error: [app package from api only] package "example/beta/bookstore/app/books" imports "example/beta/bookstore/app/authors"
+arch-lint: error: [app package from api or other features only] package "example/epsilon/bookstore/app/books/utils" imports "example/epsilon/bookstore/app/books"
+arch-lint: error: [bookstore feature facades] package "example/epsilon/bookstore/api" imports "example/epsilon/bookstore/app/books/utils": reaches into "example/epsilon/bookstore/app/books", import its facade "example/epsilon/bookstore/app/books" instead
+arch-lint: error: [clean architecture - controllers api] package "example/zeta/controllers" uses "example/zeta/infrastructure/db.Repository": leaked by exported field Controller.Repo
+arch-lint: error: [clean architecture - controllers api] package "example/zeta/controllers" uses "example/zeta/infrastructure/db.Repository": leaked by exported func New
+arch-lint: error: [clean architecture - controllers api] package "example/zeta/controllers/admin" uses "example/zeta/infrastructure/db.Repository": leaked by exported field Console.Repo
+arch-lint: error: [clean architecture - controllers without infrastructure] package "example/zeta/controllers" imports "example/zeta/infrastructure/db"
+arch-lint: error: [clean architecture - deterministic use cases] package "example/zeta/usecase" uses "time.Now"
+arch-lint: error: [clean architecture - deterministic use cases] package "example/zeta/usecase" uses "time.Now"
+arch-lint: error: [clean architecture - domain independent] package "example/zeta/domain" imports "example/zeta/usecase"
+arch-lint: error: [clean architecture - domain without database] package "example/zeta/domain" imports "example/zeta/usecase": depends on "database/sql" through example/zeta/domain -> example/zeta/usecase -> example/zeta/infrastructure/db -> database/sql
+arch-lint: error: [clean architecture - immutable domain] package "example/zeta/domain": forbidden exported variable Default
+arch-lint: error: [clean architecture - layers] package "example/zeta/controllers" imports "example/zeta/infrastructure/db": layer "controllers" may only import the layer directly below, "usecase", not "infrastructure"
+arch-lint: error: [clean architecture - layers] package "example/zeta/domain" imports "example/zeta/usecase": layer "domain" may not import higher layer "usecase"
+arch-lint: error: [no feature cycles] package "example/epsilon/bookstore/app/authors/books" imports "example/epsilon/bookstore/app/books": import cycle between groups "example/epsilon/bookstore/app/authors", "example/epsilon/bookstore/app/books"
+arch-lint: error: [no feature cycles] package "example/epsilon/bookstore/app/books/authors" imports "example/epsilon/bookstore/app/authors": import cycle between groups "example/epsilon/bookstore/app/authors", "example/epsilon/bookstore/app/books"; cut "example/epsilon/bookstore/app/books" -> "example/epsilon/bookstore/app/authors" to break it
+arch-lint: error: [stable eta api] package "example/eta/api" imports "example/eta/service": carries label "experimental" through example/eta/api -> example/eta/service -> example/eta/preview; carries label "unsafe" through example/eta/api -> example/eta/service -> example/eta/native -> unsafe
+arch-lint: warning: [deprecated packages] package "example/internal/audit" imports "example/internal/oldlog": deprecated: use the structured logger of example/internal/log.
+arch-lint: warning: [no-experimental-imports] package "example/alpha" imports "example/alpha/experimental"
+arch-lint: warning: [structured logging] package "example/internal/audit" imports "example/internal/oldlog": deprecated, use "example/internal/log" instead: oldlog is replaced by the structured logger`
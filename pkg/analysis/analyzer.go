@@ -2,15 +2,14 @@ package analysis
 
 import (
 	"fmt"
+	"go/types"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
 
-	"github.com/TheFellow/arch-lint/pkg/config"
 	"github.com/TheFellow/arch-lint/pkg/linter"
-	"github.com/bmatcuk/doublestar/v4"
 )
 
 var Analyzer = &analysis.Analyzer{
@@ -33,6 +32,11 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		return nil, err
 	}
 
+	known, err := loadBaselineCached(cfg.Baseline)
+	if err != nil {
+		return nil, err
+	}
+
 	modulePath := resolveModulePath(pass)
 	currentPkg := strings.TrimPrefix(pass.Pkg.Path(), modulePath+"/")
 
@@ -40,42 +44,139 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		return nil, nil
 	}
 
-	for _, spec := range cfg.Specs {
-		if !matchesInclude(spec, currentPkg) || matchesExclude(spec, currentPkg) {
-			continue
-		}
+	if msg, ok := linter.ComponentIssue(cfg.Components, currentPkg); ok && len(pass.Files) > 0 {
+		pass.Reportf(pass.Files[0].Package, "%s", msg)
+	}
+
+	var root string
+	if cfg.DeprecatedDocs != nil {
+		root = moduleRoot(projectDir(pass))
+	}
+
+	suppressions := make([]*linter.Suppressions, len(pass.Files))
+	for i, file := range pass.Files {
+		suppressions[i] = linter.ParseSuppressions(file)
+	}
+
+	for i, file := range pass.Files {
+		for _, imp := range file.Imports {
+			importPath := strings.Trim(imp.Path.Value, `"`)
+			importedPkg := strings.TrimPrefix(importPath, modulePath+"/")
+
+			for _, v := range linter.Check(cfg, currentPkg, importedPkg) {
+				if suppressions[i].Suppress(imp, v.Rule) || known.Contains(v) {
+					continue
+				}
+				diagnostic := analysis.Diagnostic{
+					Pos:      imp.Pos(),
+					Category: v.Severity.String(),
+					Message:  v.Diagnostic(),
+				}
+				if v.Replacement != "" {
+					diagnostic.SuggestedFixes = replaceFix(pass, file, imp, importPath, importedPkg, v.Replacement)
+				}
+				pass.Report(diagnostic)
+				for _, e := range v.Expired {
+					pass.Reportf(imp.Pos(), "%s", e)
+				}
+			}
 
-		for _, file := range pass.Files {
-			for _, imp := range file.Imports {
-				importPath := strings.Trim(imp.Path.Value, `"`)
-				importedPkg := strings.TrimPrefix(importPath, modulePath+"/")
+			if root != "" && strings.HasPrefix(importPath, modulePath+"/") {
+				notice := deprecationNotice(root, importedPkg)
+				if v := linter.CheckDeprecatedDoc(cfg.DeprecatedDocs, currentPkg, importedPkg, notice); v != nil && !suppressions[i].Suppress(imp, v.Rule) && !known.Contains(*v) {
+					pass.Report(analysis.Diagnostic{
+						Pos:      imp.Pos(),
+						Category: v.Severity.String(),
+						Message:  v.Diagnostic(),
+					})
+				}
+			}
 
-				if v := linter.CheckImport(spec, currentPkg, importedPkg); v != nil {
-					pass.Reportf(imp.Pos(), "[%s] forbidden import of %q", spec.Name, importedPkg)
+			form := linter.FormOf(imp, pass.Fset.Position(file.Pos()).Filename, modulePath)
+			if pkgName := importedName(pass, imp); pkgName != nil {
+				form.Package = pkgName.Imported().Name()
+			}
+			for _, v := range linter.CheckImportForms(cfg, currentPkg, form) {
+				if suppressions[i].Suppress(imp, v.Rule) || known.Contains(v) {
+					continue
 				}
+				diagnostic := analysis.Diagnostic{
+					Pos:      imp.Pos(),
+					Category: v.Severity.String(),
+					Message:  v.Diagnostic(),
+				}
+				if form.Name == "." || v.Rename != "" {
+					diagnostic.SuggestedFixes = renameFix(pass, file, imp, v.Rename)
+				}
+				pass.Report(diagnostic)
 			}
 		}
 	}
 
-	return nil, nil
-}
+	if cfg.HasConstructs() {
+		for _, construct := range linter.Constructs(pass.Fset, pass.Files) {
+			for _, v := range linter.CheckConstructs(cfg, currentPkg, construct) {
+				if known.Contains(v) {
+					continue
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:      construct.Pos,
+					Category: v.Severity.String(),
+					Message:  v.Diagnostic(),
+				})
+			}
+		}
+	}
 
-func matchesInclude(spec config.Spec, pkg string) bool {
-	for _, pattern := range spec.Packages.Include {
-		if ok, _ := doublestar.Match(pattern, pkg); ok {
-			return true
+	if cfg.NeedsTypes() {
+		for _, use := range linter.Uses(pass.Files, pass.TypesInfo, pass.Pkg) {
+			for _, v := range append(linter.CheckSymbols(cfg, modulePath, currentPkg, use), linter.CheckDomainUses(cfg, modulePath, currentPkg, use)...) {
+				if known.Contains(v) {
+					continue
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:      use.Ident.Pos(),
+					Category: v.Severity.String(),
+					Message:  v.Diagnostic(),
+				})
+			}
+		}
+		for _, leak := range linter.Leaks(pass.Pkg) {
+			for _, v := range linter.CheckLeaks(cfg, modulePath, currentPkg, leak) {
+				if known.Contains(v) {
+					continue
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:      leak.Pos,
+					Category: v.Severity.String(),
+					Message:  v.Diagnostic(),
+				})
+			}
+		}
+		// Only the ports among the dependencies of the adapter are known here,
+		// so adapters depending on no port are left to the CLI
+		for _, v := range linter.CheckAdapters(cfg, modulePath, currentPkg, pass.Pkg, []*types.Package{pass.Pkg}) {
+			if known.Contains(v) || len(pass.Files) == 0 {
+				continue
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:      pass.Files[0].Name.Pos(),
+				Category: v.Severity.String(),
+				Message:  v.Diagnostic(),
+			})
 		}
 	}
-	return false
-}
 
-func matchesExclude(spec config.Spec, pkg string) bool {
-	for _, pattern := range spec.Packages.Exclude {
-		if ok, _ := doublestar.Match(pattern, pkg); ok {
-			return true
+	// Rules over the whole import graph are only evaluated by the CLI
+	graphRules := linter.GraphRules(cfg)
+	for _, s := range suppressions {
+		s.Unchecked(graphRules)
+		for _, issue := range s.Issues() {
+			pass.Reportf(issue.Pos, "%s", issue.Message)
 		}
 	}
-	return false
+
+	return nil, nil
 }
 
 func isTestPackage(pass *analysis.Pass) bool {
@@ -107,6 +208,32 @@ func projectDir(pass *analysis.Pass) string {
 	return filepath.Dir(file.Name())
 }
 
+// moduleRoot returns the directory of the go.mod found walking up from dir, or the empty string
+func moduleRoot(dir string) string {
+	current := dir
+	for {
+		if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return ""
+		}
+		current = parent
+	}
+}
+
+// deprecationNotice returns the deprecation notice of the module package importedPkg, cached by directory
+func deprecationNotice(root, importedPkg string) string {
+	dir := filepath.Join(root, filepath.FromSlash(importedPkg))
+	if cached, ok := noticeCache.Load(dir); ok {
+		return cached.(string)
+	}
+	notice := linter.DirDeprecation(dir)
+	noticeCache.Store(dir, notice)
+	return notice
+}
+
 func findGoMod(dir string) (string, error) {
 	current := dir
 	for {
@@ -12,9 +12,13 @@ func TestAnalyzer(t *testing.T) {
 	testdata := analysistest.TestData()
 
 	configCache = sync.Map{}
+	baselineCache = sync.Map{}
+	noticeCache = sync.Map{}
 	configFlag = ""
 	t.Cleanup(func() {
 		configCache = sync.Map{}
+		baselineCache = sync.Map{}
+		noticeCache = sync.Map{}
 		configFlag = ""
 	})
 
@@ -23,5 +27,5 @@ func TestAnalyzer(t *testing.T) {
 		t.Fatalf("set config flag: %v", err)
 	}
 
-	analysistest.Run(t, testdata, Analyzer, "example/...")
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer, "example/...")
 }
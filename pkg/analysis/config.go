@@ -6,11 +6,16 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/TheFellow/arch-lint/pkg/baseline"
 	"github.com/TheFellow/arch-lint/pkg/config"
 )
 
 var configCache sync.Map
 
+var baselineCache sync.Map
+
+var noticeCache sync.Map
+
 type cachedConfig struct {
 	cfg *config.Config
 	err error
@@ -43,6 +48,33 @@ func loadConfigCached(startDir, flagPath string) (*config.Config, error) {
 	return cfg, loadErr
 }
 
+// NeedsTypesInfo reports whether the configuration resolved from dir, or the -config flag,
+// has rules requiring type information
+func NeedsTypesInfo(dir string) bool {
+	cfg, err := loadConfigCached(dir, configFlag)
+	return err == nil && cfg.NeedsTypes()
+}
+
+type cachedBaseline struct {
+	b   *baseline.Baseline
+	err error
+}
+
+// loadBaselineCached loads the baseline at path, or returns nil if path is empty.
+func loadBaselineCached(path string) (*baseline.Baseline, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if cached, ok := baselineCache.Load(path); ok {
+		c := cached.(cachedBaseline)
+		return c.b, c.err
+	}
+
+	b, err := baseline.Load(path)
+	baselineCache.Store(path, cachedBaseline{b, err})
+	return b, err
+}
+
 func resolveConfigPath(startDir string) (string, error) {
 	current := startDir
 	for {
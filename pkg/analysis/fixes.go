@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/TheFellow/arch-lint/pkg/linter"
+)
+
+// renameFix rewrites imp to import its package as name, or without a name if empty,
+// qualifying the references of file accordingly.
+// Returns nil without type information, as the references cannot be found.
+func renameFix(pass *analysis.Pass, file *ast.File, imp *ast.ImportSpec, name string) []analysis.SuggestedFix {
+	pkgName := importedName(pass, imp)
+	if pkgName == nil {
+		return nil
+	}
+	unnamed := name == ""
+	if unnamed {
+		name = pkgName.Imported().Name()
+	}
+
+	var edits []analysis.TextEdit
+	switch {
+	case imp.Name != nil && unnamed:
+		edits = append(edits, analysis.TextEdit{Pos: imp.Name.Pos(), End: imp.Path.Pos(), NewText: nil})
+	case imp.Name != nil:
+		edits = append(edits, analysis.TextEdit{Pos: imp.Name.Pos(), End: imp.Name.End(), NewText: []byte(name)})
+	default:
+		edits = append(edits, analysis.TextEdit{Pos: imp.Path.Pos(), End: imp.Path.Pos(), NewText: []byte(name + " ")})
+	}
+	edits = append(edits, referenceEdits(pass, file, imp, pkgName, name)...)
+
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Import %s as %s", imp.Path.Value, name),
+		TextEdits: edits,
+	}}
+}
+
+// replaceFix rewrites the path of imp to replacement, keeping the module prefix
+// trimmed from importPath to give importedPkg.
+// An unnamed import changing name has its references in file renamed,
+// or keeps its former name without type information.
+func replaceFix(pass *analysis.Pass, file *ast.File, imp *ast.ImportSpec, importPath, importedPkg, replacement string) []analysis.SuggestedFix {
+	newPath := importPath[:len(importPath)-len(importedPkg)] + replacement
+	edit := analysis.TextEdit{Pos: imp.Path.Pos(), End: imp.Path.End(), NewText: []byte(strconv.Quote(newPath))}
+	edits := []analysis.TextEdit{edit}
+	if name := linter.ImpliedName(newPath); imp.Name == nil && name != linter.ImpliedName(importPath) {
+		if pkgName := importedName(pass, imp); pkgName != nil {
+			edits = append(edits, referenceEdits(pass, file, imp, pkgName, name)...)
+		} else {
+			edits[0].NewText = []byte(linter.ImpliedName(importPath) + " " + strconv.Quote(newPath))
+		}
+	}
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Import %q instead", newPath),
+		TextEdits: edits,
+	}}
+}
+
+// importedName returns the package name declared by imp, nil without type information
+func importedName(pass *analysis.Pass, imp *ast.ImportSpec) *types.PkgName {
+	if pass.TypesInfo == nil {
+		return nil
+	}
+	var obj types.Object
+	if imp.Name != nil {
+		obj = pass.TypesInfo.Defs[imp.Name]
+	} else {
+		obj = pass.TypesInfo.Implicits[imp]
+	}
+	pkgName, _ := obj.(*types.PkgName)
+	return pkgName
+}
+
+// referenceEdits qualifies the references of file to the package imported by imp with name
+func referenceEdits(pass *analysis.Pass, file *ast.File, imp *ast.ImportSpec, pkgName *types.PkgName, name string) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	imported := pkgName.Imported()
+	dot := imp.Name != nil && imp.Name.Name == "."
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		used := pass.TypesInfo.Uses[ident]
+		switch {
+		case used == nil:
+		case dot && used.Pkg() == imported && used.Parent() == imported.Scope():
+			edits = append(edits, analysis.TextEdit{Pos: ident.Pos(), End: ident.Pos(), NewText: []byte(name + ".")})
+		case !dot && used == pkgName:
+			edits = append(edits, analysis.TextEdit{Pos: ident.Pos(), End: ident.End(), NewText: []byte(name)})
+		}
+		return true
+	})
+	return edits
+}
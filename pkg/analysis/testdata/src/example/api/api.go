@@ -1,5 +1,8 @@
 package api
 
-import "example/usecase"
+import (
+	//arch-lint:ignore clean architecture -- api is not part of the domain // want `unused suppression of \[clean architecture\]`
+	"example/usecase"
+)
 
 var _ = usecase.Service
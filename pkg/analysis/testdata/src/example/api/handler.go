@@ -0,0 +1,30 @@
+package api
+
+import "example/infrastructure"
+
+type Handler struct {
+	Conn  *infrastructure.Conn // want `\[api without infrastructure types\] forbidden use of "infrastructure.Conn": leaked by exported field Handler.Conn`
+	store infrastructure.Store
+}
+
+func New(conn *infrastructure.Conn) *Handler { // want `\[api without infrastructure types\] forbidden use of "infrastructure.Conn": leaked by exported func New`
+	return &Handler{Conn: conn, store: open()}
+}
+
+func (h *Handler) Store() infrastructure.Store { // want `\[api without infrastructure types\] forbidden use of "infrastructure.Store": leaked by exported method Handler.Store`
+	return h.store
+}
+
+func (h *Handler) Get() string {
+	return h.store.Get()
+}
+
+type Connection = infrastructure.Conn // want `\[api without infrastructure types\] forbidden use of "infrastructure.Conn": leaked by exported type Connection`
+
+type Reader interface {
+	Read() []infrastructure.Conn // want `\[api without infrastructure types\] forbidden use of "infrastructure.Conn": leaked by exported method Reader.Read`
+}
+
+func open() infrastructure.Store {
+	return nil
+}
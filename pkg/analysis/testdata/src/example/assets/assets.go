@@ -0,0 +1,10 @@
+package assets
+
+import _ "embed"
+
+//go:embed hello.txt
+var hello string
+
+func Hello() string {
+	return hello
+}
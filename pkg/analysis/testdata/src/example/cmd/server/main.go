@@ -0,0 +1,11 @@
+package main
+
+import (
+	"example/legacy/settings"
+	"example/secrets"
+)
+
+func main() {
+	_ = secrets.Token
+	_ = settings.Get("port")
+}
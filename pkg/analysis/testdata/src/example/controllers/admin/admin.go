@@ -0,0 +1,8 @@
+package admin
+
+//arch-lint:ignore controllers without infrastructure -- admin tooling reads the database directly
+import (
+	"example/infrastructure"
+)
+
+var _ = infrastructure.DB
@@ -0,0 +1,5 @@
+package jobs
+
+import "example/infrastructure"
+
+var _ = infrastructure.DB
@@ -0,0 +1,5 @@
+package legacy
+
+import "example/infrastructure"
+
+var _ = infrastructure.DB
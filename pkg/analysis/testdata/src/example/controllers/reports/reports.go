@@ -0,0 +1,5 @@
+package reports
+
+import "example/infrastructure" // want `\[controllers without infrastructure\] forbidden import of "infrastructure"` `\[controllers without infrastructure\] exception expired: except "controllers/reports" expired on 2000-01-31 \(ticket ARCH-1\)`
+
+var _ = infrastructure.DB
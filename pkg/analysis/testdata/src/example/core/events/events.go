@@ -0,0 +1,26 @@
+package events
+
+import (
+	_ "embed"
+	_ "unsafe"
+)
+
+var Default = "created" // want `\[pure core\] forbidden exported variable Default`
+
+var handlers []func(string)
+
+func init() { // want `\[pure core\] forbidden init function`
+	handlers = append(handlers, func(string) {})
+}
+
+func Publish(event string) {
+	for _, h := range handlers {
+		go h(event) // want `\[goroutines in workers\] forbidden go statement`
+	}
+}
+
+//go:linkname nanotime runtime.nanotime // want `\[no linkname\] forbidden //go:linkname directive`
+func nanotime() int64
+
+//go:embed events.txt // want `\[embed in assets\] forbidden //go:embed directive`
+var names string
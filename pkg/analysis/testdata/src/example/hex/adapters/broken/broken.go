@@ -0,0 +1,9 @@
+package broken // want `\[ports and adapters\] no exported type implements a port of hex/domain/ports/\*\*`
+
+import "example/hex/domain/ports"
+
+type Broken struct{}
+
+func (Broken) Save(order string) {}
+
+var _ ports.Store
@@ -0,0 +1,6 @@
+package cache
+
+// Cache implements ports.Store without importing the ports, which is left to the CLI
+type Cache struct{}
+
+func (Cache) Save(order string) error { return nil }
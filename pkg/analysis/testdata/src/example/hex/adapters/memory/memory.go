@@ -0,0 +1,14 @@
+package memory
+
+import "example/hex/domain/ports"
+
+type Memory struct {
+	orders []string
+}
+
+func (m *Memory) Save(order string) error {
+	m.orders = append(m.orders, order)
+	return nil
+}
+
+var _ ports.Store = (*Memory)(nil)
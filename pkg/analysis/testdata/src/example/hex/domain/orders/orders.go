@@ -0,0 +1,14 @@
+package orders
+
+import (
+	"example/hex/adapters/memory"
+	"example/hex/domain/ports"
+)
+
+type Service struct {
+	Store ports.Store
+}
+
+func NewService() *Service {
+	return &Service{Store: &memory.Memory{}} // want `\[ports and adapters\] forbidden use of "hex/adapters/memory.Memory": domain depends on adapter "hex/adapters/memory", use a port interface instead`
+}
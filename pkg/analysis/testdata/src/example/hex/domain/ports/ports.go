@@ -0,0 +1,5 @@
+package ports
+
+type Store interface {
+	Save(order string) error
+}
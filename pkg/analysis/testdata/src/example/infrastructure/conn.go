@@ -0,0 +1,7 @@
+package infrastructure
+
+type Conn struct{}
+
+type Store interface {
+	Get() string
+}
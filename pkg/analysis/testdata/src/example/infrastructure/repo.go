@@ -0,0 +1,3 @@
+package infrastructure
+
+func NewRepo() string { return DB }
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"example/internal/oldlog"       // want `\[log migration\] forbidden import of "internal/oldlog": deprecated, use "internal/log" instead: use the structured logger \(deadline 2999-12-31\)`
+	"example/internal/oldlog/level" // want `\[log migration\] forbidden import of "internal/oldlog/level": deprecated, use "internal/log/level" instead: use the structured logger \(deadline 2999-12-31\)`
+	"example/internal/oldmetrics"   // want `\[metrics removal\] forbidden import of "internal/oldmetrics": deprecated: metrics are exported by the collector \(deadline 2020-01-01 passed\)`
+)
+
+func Record(event string) {
+	oldlog.Printf(level.Info, "audit: %s", event)
+	oldmetrics.Count(event)
+}
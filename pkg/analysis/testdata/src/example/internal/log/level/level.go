@@ -0,0 +1,5 @@
+package level
+
+type Level int
+
+const Info Level = 0
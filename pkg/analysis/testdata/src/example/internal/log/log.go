@@ -0,0 +1,5 @@
+package log
+
+import "example/internal/log/level"
+
+func Printf(lvl level.Level, format string, args ...any) {}
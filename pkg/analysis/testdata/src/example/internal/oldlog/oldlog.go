@@ -0,0 +1,5 @@
+package oldlog
+
+import "example/internal/oldlog/level"
+
+func Printf(lvl level.Level, format string, args ...any) {}
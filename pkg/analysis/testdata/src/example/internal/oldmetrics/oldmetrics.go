@@ -0,0 +1,3 @@
+package oldmetrics
+
+func Count(name string) {}
@@ -0,0 +1,9 @@
+// Package settings reads the settings from the environment.
+//
+// Deprecated: settings are injected by the wiring package,
+// see wiring.Repo for an example.
+package settings
+
+func Get(key string) string {
+	return ""
+}
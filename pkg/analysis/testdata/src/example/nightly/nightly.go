@@ -0,0 +1,7 @@
+package nightly
+
+import "example/legacy/settings" // want `\[deprecated packages\] forbidden import of "legacy/settings": deprecated: settings are injected by the wiring package, see wiring.Repo for an example.`
+
+func Schedule() string {
+	return settings.Get("schedule")
+}
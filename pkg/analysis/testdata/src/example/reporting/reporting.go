@@ -0,0 +1,5 @@
+package reporting
+
+import "example/secrets" // want `\[secrets visibility\] forbidden import of "secrets": "secrets" is only visible to cmd/\*\*`
+
+var _ = secrets.Token
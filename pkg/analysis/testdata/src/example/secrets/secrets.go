@@ -0,0 +1,3 @@
+package secrets
+
+const Token = "token"
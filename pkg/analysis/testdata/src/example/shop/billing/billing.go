@@ -0,0 +1,3 @@
+package billing
+
+type Invoice struct{}
@@ -0,0 +1,3 @@
+package catalog
+
+type Product struct{}
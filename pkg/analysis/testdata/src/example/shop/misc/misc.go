@@ -0,0 +1 @@
+package misc // want `\[shop components\] package "shop/misc" belongs to no component`
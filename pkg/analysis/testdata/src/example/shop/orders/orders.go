@@ -0,0 +1,15 @@
+package orders
+
+import (
+	"example/shop/billing" // want `\[shop components\] forbidden import of "shop/billing": component "orders" may not depend on "billing"`
+	// Rules over the whole import graph are left to the CLI, which reports these if unused
+	//arch-lint:ignore no shop cycles -- catalog never imports orders
+	//arch-lint:ignore shop without sql -- catalog keeps its products in memory
+	//arch-lint:ignore stable shop -- catalog is stable
+	"example/shop/catalog"
+)
+
+type Order struct {
+	Product catalog.Product
+	Invoice billing.Invoice
+}
@@ -0,0 +1,16 @@
+package styles
+
+import (
+	"crypto/rand"  // want `\[import style\] forbidden import of "crypto/rand": must be imported as crand`
+	mr "math/rand" // want `\[import style\] forbidden import of "math/rand": must be imported as mrand`
+	. "strings"    // want `\[import style\] forbidden import of "strings": dot import is forbidden outside test files`
+	"time"
+
+	_ "embed" // want `\[import style\] forbidden import of "embed": blank import is forbidden`
+)
+
+func Pick() int {
+	b := make([]byte, 1)
+	rand.Read(b)
+	return mr.Intn(10) + len(TrimSpace(" x ")) + int(time.Second)
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"example/infrastructure"
+	"time"
+)
+
+var repo = infrastructure.NewRepo() // want `\[repositories from wiring\] forbidden use of "infrastructure.NewRepo"`
+
+func Elapsed(start time.Time) time.Duration {
+	now := time.Now() // want `\[deterministic use cases\] forbidden use of "time.Now"`
+	return now.Sub(start) + time.Since(start)
+}
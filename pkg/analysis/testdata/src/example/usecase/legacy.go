@@ -0,0 +1,4 @@
+package usecase
+
+//arch-lint:ignore clean architecture // want `suppression must name a spec and give a reason`
+import _ "example/infrastructure"
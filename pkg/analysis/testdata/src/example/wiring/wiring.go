@@ -0,0 +1,5 @@
+package wiring
+
+import "example/infrastructure"
+
+var Repo = infrastructure.NewRepo()
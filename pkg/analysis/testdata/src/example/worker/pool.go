@@ -0,0 +1,7 @@
+package worker
+
+func Start(jobs []func()) {
+	for _, job := range jobs {
+		go job()
+	}
+}
@@ -0,0 +1,124 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/TheFellow/arch-lint/pkg/linter"
+)
+
+// Baseline is a recorded set of known violations.
+// Violations found in the baseline do not fail a run.
+type Baseline struct {
+	Violations []Entry `yaml:"violations"`
+}
+
+// Entry identifies a single baselined violation.
+type Entry struct {
+	Rule    string `yaml:"rule"`
+	Package string `yaml:"package"`
+	Import  string `yaml:"import"`
+	// Symbol is set for violations of symbol rules,
+	// and names the construct of violations of construct rules
+	Symbol string `yaml:"symbol,omitempty"`
+	// Declaration is set for violations of export rules, naming the exported declaration leaking Symbol
+	Declaration string `yaml:"declaration,omitempty"`
+}
+
+func (e Entry) String() string {
+	if e.Import == "" && e.Symbol != "" {
+		return fmt.Sprintf("[%s] package %q: %s", e.Rule, e.Package, e.Symbol)
+	}
+	if e.Import == "" {
+		return fmt.Sprintf("[%s] package %q", e.Rule, e.Package)
+	}
+	if e.Declaration != "" {
+		return fmt.Sprintf("[%s] package %q uses %q: leaked by exported %s", e.Rule, e.Package, e.Symbol, e.Declaration)
+	}
+	if e.Symbol != "" {
+		return fmt.Sprintf("[%s] package %q uses %q", e.Rule, e.Package, e.Symbol)
+	}
+	return fmt.Sprintf("[%s] package %q imports %q", e.Rule, e.Package, e.Import)
+}
+
+func entryOf(v linter.Violation) Entry {
+	return Entry{Rule: v.Rule, Package: v.Package, Import: v.Import, Symbol: v.Symbol, Declaration: v.Declaration}
+}
+
+// New creates a baseline from the given violations.
+func New(violations []linter.Violation) *Baseline {
+	b := &Baseline{}
+	for _, v := range violations {
+		e := entryOf(v)
+		if !slices.Contains(b.Violations, e) {
+			b.Violations = append(b.Violations, e)
+		}
+	}
+	slices.SortFunc(b.Violations, func(x, y Entry) int {
+		return strings.Compare(x.String(), y.String())
+	})
+	return b
+}
+
+// Load reads a baseline file. A missing file yields an empty baseline.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Baseline{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var b Baseline
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return &b, nil
+}
+
+// Write stores the baseline at path.
+func (b *Baseline) Write(path string) error {
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Contains reports whether v is recorded in the baseline.
+func (b *Baseline) Contains(v linter.Violation) bool {
+	if b == nil {
+		return false
+	}
+	return slices.Contains(b.Violations, entryOf(v))
+}
+
+// Filter splits violations into those not recorded in the baseline,
+// and returns the baseline entries that no longer occur.
+func (b *Baseline) Filter(violations []linter.Violation) (fresh []linter.Violation, fixed []Entry) {
+	seen := make(map[Entry]bool)
+	for _, v := range violations {
+		if b.Contains(v) {
+			seen[entryOf(v)] = true
+			continue
+		}
+		fresh = append(fresh, v)
+	}
+	if b == nil {
+		return fresh, nil
+	}
+	for _, e := range b.Violations {
+		if !seen[e] {
+			fixed = append(fixed, e)
+		}
+	}
+	return fresh, fixed
+}
@@ -0,0 +1,78 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestBaseline_Filter(t *testing.T) {
+	t.Parallel()
+	known := linter.Violation{Rule: "r", Package: "a", Import: "b"}
+	fixed := linter.Violation{Rule: "r", Package: "a", Import: "c"}
+	fresh := linter.Violation{Rule: "r", Package: "a", Import: "d"}
+	b := New([]linter.Violation{known, fixed, known})
+
+	gotFresh, gotFixed := b.Filter([]linter.Violation{known, fresh})
+	testutil.Equals(t, gotFresh, []linter.Violation{fresh})
+	testutil.Equals(t, gotFixed, []Entry{{Rule: "r", Package: "a", Import: "c"}})
+}
+
+func TestBaseline_WriteLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "baseline.yml")
+	want := New([]linter.Violation{{Rule: "r", Package: "a", Import: "b"}, {Rule: "s", Package: "a", Import: "time", Symbol: "time.Now"}})
+	testutil.Equals(t, want.Write(path), nil)
+
+	got, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, got, want)
+
+	missing, err := Load(filepath.Join(t.TempDir(), "missing.yml"))
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, missing, &Baseline{})
+}
+
+func TestBaseline_Symbols(t *testing.T) {
+	t.Parallel()
+	now := linter.Violation{Rule: "r", Package: "a", Import: "time", Symbol: "time.Now"}
+	since := linter.Violation{Rule: "r", Package: "a", Import: "time", Symbol: "time.Since"}
+	b := New([]linter.Violation{now})
+
+	testutil.Equals(t, b.Contains(now), true)
+	testutil.Equals(t, b.Contains(since), false)
+	testutil.Equals(t, b.Violations[0].String(), `[r] package "a" uses "time.Now"`)
+}
+
+func TestBaseline_Constructs(t *testing.T) {
+	t.Parallel()
+	spec := config.Spec{
+		Name:       "r",
+		Packages:   config.Packages{Include: []string{"**"}},
+		Constructs: config.Constructs{Forbid: []string{config.ConstructInit, config.ConstructGo}},
+	}
+	initFunc := *linter.CheckConstruct(spec, "a", linter.Construct{Kind: config.ConstructInit, Name: "a.go", Description: "init function"})
+	publish := *linter.CheckConstruct(spec, "a", linter.Construct{Kind: config.ConstructGo, Name: "Publish", Description: "go statement"})
+	send := *linter.CheckConstruct(spec, "a", linter.Construct{Kind: config.ConstructGo, Name: "Send", Description: "go statement"})
+	b := New([]linter.Violation{initFunc, publish})
+
+	testutil.Equals(t, b.Contains(initFunc), true)
+	testutil.Equals(t, b.Contains(publish), true)
+	testutil.Equals(t, b.Contains(send), false)
+	testutil.Equals(t, b.Violations[0].String(), `[r] package "a": go Publish`)
+}
+
+func TestBaseline_Leaks(t *testing.T) {
+	t.Parallel()
+	field := linter.Violation{Rule: "r", Package: "a", Import: "db", Symbol: "db.Repository", Declaration: "field Controller.Repo"}
+	newFunc := linter.Violation{Rule: "r", Package: "a", Import: "db", Symbol: "db.Repository", Declaration: "func New"}
+	b := New([]linter.Violation{field})
+
+	testutil.Equals(t, b.Contains(field), true)
+	testutil.Equals(t, b.Contains(newFunc), false)
+	testutil.Equals(t, len(New([]linter.Violation{field, newFunc}).Violations), 2)
+	testutil.Equals(t, b.Violations[0].String(), `[r] package "a" uses "db.Repository": leaked by exported field Controller.Repo`)
+}
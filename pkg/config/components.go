@@ -0,0 +1,54 @@
+package config
+
+import "fmt"
+
+// Components declares named components and the components each may depend on.
+// An import between two components is only allowed if it is listed in depends_on.
+type Components struct {
+	// Name identifies component violations, defaults to "components"
+	Name     string   `yaml:"name"`
+	Severity Severity `yaml:"severity"`
+	// Packages scopes the packages that must belong to exactly one component, defaults to every package
+	Packages Packages    `yaml:"packages"`
+	List     []Component `yaml:"list"`
+}
+
+// Component is a named set of packages within Components
+type Component struct {
+	Name      string   `yaml:"name"`
+	Packages  []string `yaml:"packages"`
+	DependsOn []string `yaml:"depends_on"`
+}
+
+func (c *Components) validate() error {
+	if c.Name == "" {
+		c.Name = "components"
+	}
+	if c.Severity == "" {
+		c.Severity = SeverityError
+	}
+	if len(c.Packages.Include) == 0 {
+		c.Packages.Include = []string{"**"}
+	}
+	if len(c.List) == 0 {
+		return fmt.Errorf("components '%s' must specify 'list'", c.Name)
+	}
+	seen := make(map[string]bool)
+	for _, component := range c.List {
+		if seen[component.Name] {
+			return fmt.Errorf("components '%s' declares component '%s' more than once", c.Name, component.Name)
+		}
+		seen[component.Name] = true
+		if len(component.Packages) == 0 {
+			return fmt.Errorf("component '%s' must specify 'packages'", component.Name)
+		}
+	}
+	for _, component := range c.List {
+		for _, dep := range component.DependsOn {
+			if !seen[dep] {
+				return fmt.Errorf("component '%s' depends on unknown component '%s'", component.Name, dep)
+			}
+		}
+	}
+	return nil
+}
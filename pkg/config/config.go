@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	_ "embed"
 	"github.com/goccy/go-yaml"
@@ -14,20 +16,135 @@ import (
 var schemaData []byte
 
 type Config struct {
-	IncludeTests bool   `yaml:"include_tests"`
-	Specs        []Spec `yaml:"specs"`
+	// Path is the file the configuration was loaded from
+	Path         string        `yaml:"-"`
+	IncludeTests bool          `yaml:"include_tests"`
+	Baseline     string        `yaml:"baseline"`
+	Specs        []Spec        `yaml:"specs"`
+	Layers       *Layers       `yaml:"layers"`
+	Components   *Components   `yaml:"components"`
+	Cycles       []Cycles      `yaml:"cycles"`
+	Visibility   []Visibility  `yaml:"visibility"`
+	Facades      []Facade      `yaml:"facades"`
+	Hexagonal    []Hexagonal   `yaml:"hexagonal"`
+	Deprecate    []Deprecation `yaml:"deprecate"`
+	// DeprecatedDocs, if set, forbids importing packages documented as deprecated
+	DeprecatedDocs *DeprecatedDocs `yaml:"deprecated_docs"`
+	Labels         []Label         `yaml:"labels"`
 }
 
 type Spec struct {
-	Name     string   `yaml:"name"`
-	Packages Packages `yaml:"packages"`
-	Rules    Rules    `yaml:"rules"`
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Severity    Severity   `yaml:"severity"`
+	Packages    Packages   `yaml:"packages"`
+	Rules       Rules      `yaml:"rules"`
+	Symbols     Symbols    `yaml:"symbols"`
+	Exports     Exports    `yaml:"exports"`
+	Constructs  Constructs `yaml:"constructs"`
+	Imports     Imports    `yaml:"imports"`
+	Labels      LabelRules `yaml:"labels"`
+}
+
+// Severity of the violations reported for a spec
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// ParseSeverity converts a severity name into a Severity
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(s); sev {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return sev, nil
+	}
+	return "", fmt.Errorf("unknown severity %q: must be error, warning or info", s)
+}
+
+// Rank orders severities from info (lowest) to error (highest).
+// An empty severity is an error.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityInfo:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (s Severity) String() string {
+	if s == "" {
+		return string(SeverityError)
+	}
+	return string(s)
 }
 
 type Rules struct {
 	Forbid []string `yaml:"forbid"`
-	Except []string `yaml:"except"`
-	Exempt []string `yaml:"exempt"`
+	// Allow forbids every import not matching one of its patterns
+	Allow  []string    `yaml:"allow"`
+	Except []Exception `yaml:"except"`
+	Exempt []Exception `yaml:"exempt"`
+	// Transitive also forbids depending on a forbidden package through other packages
+	Transitive bool `yaml:"transitive"`
+}
+
+// Exception is an except or exempt pattern.
+// It is written either as a plain pattern or as an object recording
+// why it exists and when it stops applying.
+type Exception struct {
+	Pattern string
+	Reason  string
+	Ticket  string
+	// Expires is the last day the exception applies, or the zero time if it never expires
+	Expires time.Time
+}
+
+const dateLayout = "2006-01-02"
+
+func (e *Exception) UnmarshalYAML(unmarshal func(any) error) error {
+	var pattern string
+	if err := unmarshal(&pattern); err == nil {
+		*e = Exception{Pattern: pattern}
+		return nil
+	}
+
+	var raw struct {
+		Pattern string `yaml:"pattern"`
+		Reason  string `yaml:"reason"`
+		Ticket  string `yaml:"ticket"`
+		Expires string `yaml:"expires"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*e = Exception{Pattern: raw.Pattern, Reason: raw.Reason, Ticket: raw.Ticket}
+	if raw.Expires != "" {
+		expires, err := time.ParseInLocation(dateLayout, raw.Expires, time.Local)
+		if err != nil {
+			return fmt.Errorf("exception %q: invalid expires date %q: want YYYY-MM-DD", raw.Pattern, raw.Expires)
+		}
+		e.Expires = expires
+	}
+	return nil
+}
+
+// Expired reports whether the exception no longer applies at now.
+func (e Exception) Expired(now time.Time) bool {
+	return !e.Expires.IsZero() && !now.Before(e.Expires.AddDate(0, 0, 1))
+}
+
+// ExpiresOn returns the expiry date formatted as YYYY-MM-DD.
+func (e Exception) ExpiresOn() string {
+	if e.Expires.IsZero() {
+		return ""
+	}
+	return e.Expires.Format(dateLayout)
 }
 
 type Packages struct {
@@ -47,15 +164,71 @@ func Load(path string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
-	if len(cfg.Specs) == 0 {
-		return nil, fmt.Errorf("config must contain at least one spec")
+	cfg.Path = path
+	if cfg.Baseline != "" && !filepath.IsAbs(cfg.Baseline) {
+		// The baseline is relative to the config file
+		cfg.Baseline = filepath.Join(filepath.Dir(path), cfg.Baseline)
+	}
+	if len(cfg.Specs) == 0 && cfg.Layers == nil && cfg.Components == nil && len(cfg.Cycles) == 0 && len(cfg.Visibility) == 0 && len(cfg.Facades) == 0 && len(cfg.Hexagonal) == 0 && len(cfg.Deprecate) == 0 && cfg.DeprecatedDocs == nil {
+		return nil, fmt.Errorf("config must contain at least one spec, layers, components, cycles, visibility, facades, hexagonal, deprecate or deprecated_docs")
+	}
+	if cfg.Layers != nil {
+		if err := cfg.Layers.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Components != nil {
+		if err := cfg.Components.validate(); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cfg.Cycles {
+		if err := cfg.Cycles[i].validate(&cfg); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cfg.Visibility {
+		if err := cfg.Visibility[i].validate(); err != nil {
+			return nil, err
+		}
 	}
-	for _, r := range cfg.Specs {
+	for i := range cfg.Facades {
+		if err := cfg.Facades[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cfg.Hexagonal {
+		if err := cfg.Hexagonal[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cfg.Deprecate {
+		if err := cfg.Deprecate[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.DeprecatedDocs != nil {
+		cfg.DeprecatedDocs.validate()
+	}
+	for i := range cfg.Labels {
+		if err := cfg.Labels[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	for i, r := range cfg.Specs {
+		if r.Severity == "" {
+			cfg.Specs[i].Severity = SeverityError
+		}
 		if len(r.Packages.Include) == 0 {
 			return nil, fmt.Errorf("rule '%s' must specify 'packages'", r.Name)
 		}
-		if len(r.Rules.Forbid) == 0 {
-			return nil, fmt.Errorf("rule '%s' must specify 'forbid' rules", r.Name)
+		if len(r.Rules.Forbid) == 0 && len(r.Rules.Allow) == 0 && len(r.Symbols.Forbid) == 0 && len(r.Exports.Forbid) == 0 && len(r.Constructs.Forbid) == 0 && r.Imports.Empty() && len(r.Labels.Forbid) == 0 {
+			return nil, fmt.Errorf("rule '%s' must specify 'forbid' or 'allow' rules, 'symbols', 'exports', 'constructs', 'imports' or 'labels'", r.Name)
+		}
+		for _, name := range r.Labels.Forbid {
+			if _, ok := cfg.Label(name); !ok {
+				return nil, fmt.Errorf("rule '%s' forbids unknown label '%s'", r.Name, name)
+			}
 		}
 	}
 	return &cfg, nil
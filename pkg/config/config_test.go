@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/TheFellow/arch-lint/pkg/testutil"
 )
@@ -23,3 +24,301 @@ func TestLoad_SchemaValidation(t *testing.T) {
 	_, err = Load(bad)
 	testutil.ErrorIf(t, err == nil, "expected error")
 }
+
+func TestLoad_Severity(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("specs:\n  - name: default\n    packages:\n      include: [pkg]\n    rules:\n      forbid: [other]\n  - name: warn\n    severity: warning\n    packages:\n      include: [pkg]\n    rules:\n      forbid: [other]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Specs[0].Severity, SeverityError)
+	testutil.Equals(t, cfg.Specs[1].Severity, SeverityWarning)
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("specs:\n  - name: bad\n    severity: fatal\n    packages:\n      include: [pkg]\n    rules:\n      forbid: [other]\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Exceptions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte(`specs:
+  - name: test
+    packages:
+      include: [pkg]
+    rules:
+      forbid: [other]
+      except:
+        - plain
+        - pattern: temporary
+          reason: legacy code
+          ticket: ARCH-1
+          expires: 2024-01-31
+`), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	except := cfg.Specs[0].Rules.Except
+	testutil.Equals(t, except[0], Exception{Pattern: "plain"})
+	testutil.Equals(t, except[1].Pattern, "temporary")
+	testutil.Equals(t, except[1].Reason, "legacy code")
+	testutil.Equals(t, except[1].Ticket, "ARCH-1")
+	testutil.Equals(t, except[1].ExpiresOn(), "2024-01-31")
+
+	lastDay := time.Date(2024, 1, 31, 23, 59, 0, 0, time.Local)
+	testutil.Equals(t, except[0].Expired(lastDay), false)
+	testutil.Equals(t, except[1].Expired(lastDay), false)
+	testutil.Equals(t, except[1].Expired(lastDay.Add(time.Minute)), true)
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("specs:\n  - name: bad\n    packages:\n      include: [pkg]\n    rules:\n      forbid: [other]\n      except:\n        - pattern: temporary\n          expires: soon\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Layers(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte(`layers:
+  strict: true
+  order:
+    - name: api
+      packages: ["app/api/**"]
+    - name: domain
+      packages: ["app/domain/**"]
+`), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Layers.Name, "layers")
+	testutil.Equals(t, cfg.Layers.Severity, SeverityError)
+	testutil.Equals(t, cfg.Layers.Strict, true)
+	testutil.Equals(t, cfg.Layers.Order[1], Layer{Name: "domain", Packages: []string{"app/domain/**"}})
+
+	duplicate := dir + "/duplicate.yml"
+	os.WriteFile(duplicate, []byte("layers:\n  order:\n    - name: api\n      packages: [a]\n    - name: api\n      packages: [b]\n"), 0o644)
+	_, err = Load(duplicate)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Components(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte(`components:
+  list:
+    - name: orders
+      packages: ["app/orders/**"]
+      depends_on: [catalog]
+    - name: catalog
+      packages: ["app/catalog/**"]
+`), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Components.Name, "components")
+	testutil.Equals(t, cfg.Components.Severity, SeverityError)
+	testutil.Equals(t, cfg.Components.Packages.Include, []string{"**"})
+	testutil.Equals(t, cfg.Components.List[0].DependsOn, []string{"catalog"})
+
+	unknown := dir + "/unknown.yml"
+	os.WriteFile(unknown, []byte("components:\n  list:\n    - name: orders\n      packages: [a]\n      depends_on: [billing]\n"), 0o644)
+	_, err = Load(unknown)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Cycles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("cycles:\n  - group: \"app/{feature}/**\"\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Cycles[0], Cycles{Name: "cycles", Severity: SeverityError, Group: "app/{feature}/**"})
+
+	for name, data := range map[string]string{
+		"no variable":   "cycles:\n  - group: \"app/*/**\"\n",
+		"no components": "cycles:\n  - components: true\n",
+	} {
+		bad := dir + "/bad.yml"
+		os.WriteFile(bad, []byte(data), 0o644)
+		_, err = Load(bad)
+		testutil.ErrorIf(t, err == nil, "%s: expected error", name)
+	}
+}
+
+func TestLoad_Allow(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("specs:\n  - name: core\n    packages:\n      include: [domain/**]\n    rules:\n      allow: [errors, time, \"domain/**\"]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Specs[0].Rules.Allow, []string{"errors", "time", "domain/**"})
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("specs:\n  - name: core\n    packages:\n      include: [domain/**]\n    rules:\n      except: [domain]\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Visibility(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("visibility:\n  - packages:\n      include: [infrastructure/**]\n    visible_to: [cmd/**, wiring/**]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Visibility[0].Name, "visibility")
+	testutil.Equals(t, cfg.Visibility[0].Severity, SeverityError)
+	testutil.Equals(t, cfg.Visibility[0].VisibleTo, []string{"cmd/**", "wiring/**"})
+}
+
+func TestLoad_Facades(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("facades:\n  - root: \"app/{feature}\"\n    public: [api]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Facades[0], Facade{Name: "facades", Severity: SeverityError, Root: "app/{feature}", Public: []string{"api"}})
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("facades:\n  - public: [api]\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Exports(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("specs:\n  - name: api\n    packages:\n      include: [api/**]\n    exports:\n      forbid: [infrastructure/**]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Specs[0].Exports.Forbid, []string{"infrastructure/**"})
+	testutil.Equals(t, cfg.NeedsTypes(), true)
+}
+
+func TestLoad_Hexagonal(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("hexagonal:\n  - ports: [domain/ports/**]\n    adapters: [adapters/**]\n    domain: [domain/**]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Hexagonal[0], Hexagonal{Name: "hexagonal", Severity: SeverityError, Ports: []string{"domain/ports/**"}, Adapters: []string{"adapters/**"}, Domain: []string{"domain/**"}})
+	testutil.Equals(t, cfg.NeedsTypes(), true)
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("hexagonal:\n  - ports: [domain/ports/**]\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Constructs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("specs:\n  - name: workers\n    packages:\n      include: [\"**\"]\n    constructs:\n      forbid: [go, linkname]\n      except: [worker/**]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Specs[0].Constructs.Forbid, []string{ConstructGo, ConstructLinkname})
+	testutil.Equals(t, cfg.HasConstructs(), true)
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("specs:\n  - name: workers\n    packages:\n      include: [\"**\"]\n    constructs:\n      forbid: [goto]\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Imports(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("specs:\n  - name: style\n    packages:\n      include: [\"**\"]\n    imports:\n      aliases:\n        k8s.io/api/core/v1: corev1\n      forbid_dot: true\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Specs[0].Imports, Imports{Aliases: map[string]string{"k8s.io/api/core/v1": "corev1"}, ForbidDot: true})
+	testutil.Equals(t, cfg.NeedsTypes(), true)
+
+	blank := dir + "/blank.yml"
+	os.WriteFile(blank, []byte("specs:\n  - name: style\n    packages:\n      include: [\"**\"]\n    imports:\n      forbid_blank: [\"**\"]\n"), 0o644)
+	cfg, err = Load(blank)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.NeedsTypes(), false)
+
+	bad := dir + "/bad.yml"
+	os.WriteFile(bad, []byte("specs:\n  - name: style\n    packages:\n      include: [\"**\"]\n    imports: {}\n"), 0o644)
+	_, err = Load(bad)
+	testutil.ErrorIf(t, err == nil, "expected error")
+}
+
+func TestLoad_Deprecate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("deprecate:\n  - from: internal/oldlog\n    to: internal/log\n    compatible: true\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Deprecate[0].Name, "deprecate")
+	testutil.Equals(t, cfg.Deprecate[0].Severity, SeverityWarning)
+	testutil.Equals(t, cfg.Deprecate[0].Overdue(time.Now()), false)
+
+	for name, data := range map[string]string{
+		"compatible without to": "deprecate:\n  - from: internal/oldlog\n    compatible: true\n",
+		"invalid deadline":      "deprecate:\n  - from: internal/oldlog\n    deadline: 2020-13-01\n",
+	} {
+		bad := dir + "/bad.yml"
+		os.WriteFile(bad, []byte(data), 0o644)
+		_, err = Load(bad)
+		testutil.ErrorIf(t, err == nil, "%s: expected error", name)
+	}
+}
+
+func TestLoad_DeprecatedDocs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("deprecated_docs: {}\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.DeprecatedDocs, &DeprecatedDocs{Name: "deprecated packages", Severity: SeverityError})
+}
+
+func TestLoad_Labels(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("labels:\n  - name: unsafe\n    imports: [unsafe]\nspecs:\n  - name: safe api\n    packages:\n      include: [api/**]\n    labels:\n      forbid: [unsafe]\n"), 0o644)
+	cfg, err := Load(path)
+	testutil.Equals(t, err, nil)
+	testutil.Equals(t, cfg.Labels, []Label{{Name: "unsafe", Imports: []string{"unsafe"}}})
+	testutil.Equals(t, cfg.Specs[0].Labels.Forbid, []string{"unsafe"})
+
+	for name, data := range map[string]string{
+		"unknown label": "labels:\n  - name: unsafe\n    imports: [unsafe]\nspecs:\n  - name: safe api\n    packages:\n      include: [api/**]\n    labels:\n      forbid: [cgo]\n",
+		"empty label":   "labels:\n  - name: unsafe\nspecs:\n  - name: safe api\n    packages:\n      include: [api/**]\n    labels:\n      forbid: [unsafe]\n",
+	} {
+		bad := dir + "/bad.yml"
+		os.WriteFile(bad, []byte(data), 0o644)
+		_, err = Load(bad)
+		testutil.ErrorIf(t, err == nil, "%s: expected error", name)
+	}
+}
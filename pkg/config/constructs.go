@@ -0,0 +1,33 @@
+package config
+
+// Language constructs a spec can forbid
+const (
+	// ConstructGo is a go statement
+	ConstructGo = "go"
+	// ConstructInit is a func init()
+	ConstructInit = "init"
+	// ConstructExportedVar is an exported package-level variable
+	ConstructExportedVar = "exported-var"
+	// ConstructLinkname is a //go:linkname directive
+	ConstructLinkname = "linkname"
+	// ConstructEmbed is a //go:embed directive
+	ConstructEmbed = "embed"
+)
+
+// Constructs forbids language constructs in the packages of a spec
+type Constructs struct {
+	Forbid []string `yaml:"forbid"`
+	// Except patterns match the packages still allowed to use the constructs
+	Except []Exception `yaml:"except"`
+}
+
+// HasConstructs reports whether any spec of cfg forbids constructs,
+// which requires the full syntax of the packages
+func (cfg *Config) HasConstructs() bool {
+	for _, spec := range cfg.Specs {
+		if len(spec.Constructs.Forbid) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Cycles forbids import cycles between groups of packages.
+// Go already forbids cycles between packages, but not between the directories grouping them.
+type Cycles struct {
+	// Name identifies cycle violations, defaults to "cycles"
+	Name     string   `yaml:"name"`
+	Severity Severity `yaml:"severity"`
+	// Group is a pattern capturing the group of a package, such as "app/{feature}/**"
+	Group string `yaml:"group"`
+	// Components groups packages by their component instead
+	Components bool `yaml:"components"`
+}
+
+var groupVariable = regexp.MustCompile(`(^|/)\{[^!/{}][^/{}]*\}(/|$)`)
+
+func (c *Cycles) validate(cfg *Config) error {
+	if c.Name == "" {
+		c.Name = "cycles"
+	}
+	if c.Severity == "" {
+		c.Severity = SeverityError
+	}
+	switch {
+	case c.Components && c.Group != "":
+		return fmt.Errorf("cycles '%s' must specify either 'group' or 'components', not both", c.Name)
+	case c.Components && cfg.Components == nil:
+		return fmt.Errorf("cycles '%s' groups by components but no components are declared", c.Name)
+	case !c.Components && !groupVariable.MatchString(c.Group):
+		return fmt.Errorf("cycles '%s' must specify a 'group' capturing a {variable}", c.Name)
+	}
+	return nil
+}
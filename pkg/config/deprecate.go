@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Deprecation reports the imports of a deprecated package, naming its replacement
+type Deprecation struct {
+	// Name identifies deprecation violations, defaults to "deprecate"
+	Name string `yaml:"name"`
+	// Severity before the deadline, defaults to warning. Imports are errors once the deadline has passed.
+	Severity Severity `yaml:"severity"`
+	// From is the deprecated package, its subpackages are deprecated too
+	From string `yaml:"from"`
+	// To is the package replacing From, if any
+	To string `yaml:"to"`
+	// Message explains the deprecation
+	Message string `yaml:"message"`
+	// Deadline is the last day From may be imported without an error, as YYYY-MM-DD
+	Deadline string `yaml:"deadline"`
+	// Compatible is set when To has the API of From, so that imports can be rewritten to it
+	Compatible bool `yaml:"compatible"`
+
+	deadline time.Time
+}
+
+func (d *Deprecation) validate() error {
+	if d.Name == "" {
+		d.Name = "deprecate"
+	}
+	if d.Severity == "" {
+		d.Severity = SeverityWarning
+	}
+	if d.From == "" {
+		return fmt.Errorf("deprecate '%s' must specify 'from'", d.Name)
+	}
+	if d.Compatible && d.To == "" {
+		return fmt.Errorf("deprecate '%s' must specify 'to' to be compatible", d.Name)
+	}
+	if d.Deadline != "" {
+		deadline, err := time.ParseInLocation(dateLayout, d.Deadline, time.Local)
+		if err != nil {
+			return fmt.Errorf("deprecate '%s': invalid deadline %q: want YYYY-MM-DD", d.Name, d.Deadline)
+		}
+		d.deadline = deadline
+	}
+	return nil
+}
+
+// Overdue reports whether the deadline has passed at now
+func (d Deprecation) Overdue(now time.Time) bool {
+	return !d.deadline.IsZero() && !now.Before(d.deadline.AddDate(0, 0, 1))
+}
+
+// DeprecatedDocs forbids importing the packages of the module
+// whose documentation has a "Deprecated:" paragraph
+type DeprecatedDocs struct {
+	// Name identifies the violations, defaults to "deprecated packages"
+	Name     string   `yaml:"name"`
+	Severity Severity `yaml:"severity"`
+	// Except lists patterns of the packages still allowed to import deprecated packages
+	Except []Exception `yaml:"except"`
+}
+
+func (d *DeprecatedDocs) validate() {
+	if d.Name == "" {
+		d.Name = "deprecated packages"
+	}
+	if d.Severity == "" {
+		d.Severity = SeverityError
+	}
+}
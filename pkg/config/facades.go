@@ -0,0 +1,28 @@
+package config
+
+import "fmt"
+
+// Facade limits importers from outside a component to the component root
+// and its public subpackages, like Go's internal/ rule with configurable boundaries.
+type Facade struct {
+	// Name identifies facade violations, defaults to "facades"
+	Name     string   `yaml:"name"`
+	Severity Severity `yaml:"severity"`
+	// Root is a pattern matching the component roots, such as "app/{feature}"
+	Root string `yaml:"root"`
+	// Public lists glob patterns of the subpackages importable from outside, relative to the root
+	Public []string `yaml:"public"`
+}
+
+func (f *Facade) validate() error {
+	if f.Name == "" {
+		f.Name = "facades"
+	}
+	if f.Severity == "" {
+		f.Severity = SeverityError
+	}
+	if f.Root == "" {
+		return fmt.Errorf("facades '%s' must specify 'root'", f.Name)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import "fmt"
+
+// Hexagonal checks a ports and adapters architecture: every adapter package
+// implements a port interface, and the domain depends on ports, never on adapters.
+type Hexagonal struct {
+	// Name identifies hexagonal violations, defaults to "hexagonal"
+	Name     string   `yaml:"name"`
+	Severity Severity `yaml:"severity"`
+	// Ports lists glob patterns of the packages declaring the port interfaces
+	Ports []string `yaml:"ports"`
+	// Adapters lists glob patterns of the adapter packages
+	Adapters []string `yaml:"adapters"`
+	// Domain lists glob patterns of the packages that may not use adapters
+	Domain []string `yaml:"domain"`
+}
+
+func (h *Hexagonal) validate() error {
+	if h.Name == "" {
+		h.Name = "hexagonal"
+	}
+	if h.Severity == "" {
+		h.Severity = SeverityError
+	}
+	if len(h.Ports) == 0 || len(h.Adapters) == 0 {
+		return fmt.Errorf("hexagonal '%s' must specify 'ports' and 'adapters'", h.Name)
+	}
+	return nil
+}
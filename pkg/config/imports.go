@@ -0,0 +1,16 @@
+package config
+
+// Imports restricts the form of the imports of a spec's packages
+type Imports struct {
+	// Aliases maps import paths to the name they must be imported as
+	Aliases map[string]string `yaml:"aliases"`
+	// ForbidDot forbids dot imports outside test files
+	ForbidDot bool `yaml:"forbid_dot"`
+	// ForbidBlank lists patterns of the packages that may not be blank imported
+	ForbidBlank []string `yaml:"forbid_blank"`
+}
+
+// Empty reports whether no import form is restricted
+func (i Imports) Empty() bool {
+	return len(i.Aliases) == 0 && !i.ForbidDot && len(i.ForbidBlank) == 0
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Label names a set of packages. Every package importing them, directly or not, carries the label too.
+type Label struct {
+	Name string `yaml:"name"`
+	// Packages lists patterns of the packages carrying the label
+	Packages []string `yaml:"packages"`
+	// Imports lists patterns of the packages whose importers in the module carry the label, such as "unsafe" or "C"
+	Imports []string `yaml:"imports"`
+}
+
+// LabelRules forbids the packages of a spec from carrying labels
+type LabelRules struct {
+	Forbid []string `yaml:"forbid"`
+}
+
+func (l *Label) validate() error {
+	if l.Name == "" {
+		return fmt.Errorf("labels must specify 'name'")
+	}
+	if len(l.Packages) == 0 && len(l.Imports) == 0 {
+		return fmt.Errorf("label '%s' must specify 'packages' or 'imports'", l.Name)
+	}
+	return nil
+}
+
+// Label returns the label of cfg named name
+func (cfg *Config) Label(name string) (Label, bool) {
+	i := slices.IndexFunc(cfg.Labels, func(l Label) bool { return l.Name == name })
+	if i < 0 {
+		return Label{}, false
+	}
+	return cfg.Labels[i], true
+}
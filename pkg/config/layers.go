@@ -0,0 +1,43 @@
+package config
+
+import "fmt"
+
+// Layers declares an ordered stack of layers, from the top layer down.
+// Upper layers may import lower layers, but never the other way around.
+type Layers struct {
+	// Name identifies layer violations, defaults to "layers"
+	Name     string   `yaml:"name"`
+	Severity Severity `yaml:"severity"`
+	// Strict only allows a layer to import the layer directly below it
+	Strict bool    `yaml:"strict"`
+	Order  []Layer `yaml:"order"`
+}
+
+// Layer is a named set of packages within Layers
+type Layer struct {
+	Name     string   `yaml:"name"`
+	Packages []string `yaml:"packages"`
+}
+
+func (l *Layers) validate() error {
+	if l.Name == "" {
+		l.Name = "layers"
+	}
+	if l.Severity == "" {
+		l.Severity = SeverityError
+	}
+	if len(l.Order) == 0 {
+		return fmt.Errorf("layers '%s' must specify 'order'", l.Name)
+	}
+	seen := make(map[string]bool)
+	for _, layer := range l.Order {
+		if seen[layer.Name] {
+			return fmt.Errorf("layers '%s' declares layer '%s' more than once", l.Name, layer.Name)
+		}
+		seen[layer.Name] = true
+		if len(layer.Packages) == 0 {
+			return fmt.Errorf("layer '%s' must specify 'packages'", layer.Name)
+		}
+	}
+	return nil
+}
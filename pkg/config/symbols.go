@@ -0,0 +1,34 @@
+package config
+
+// Symbols forbids referencing objects of other packages.
+// Objects are named "<package path>.<name>", and methods "<package path>.<type>.<method>".
+type Symbols struct {
+	Forbid []string    `yaml:"forbid"`
+	Except []Exception `yaml:"except"`
+	Exempt []Exception `yaml:"exempt"`
+}
+
+// Exports forbids the exported declarations of a package from mentioning types of other packages
+type Exports struct {
+	Forbid []string    `yaml:"forbid"`
+	Except []Exception `yaml:"except"`
+	Exempt []Exception `yaml:"exempt"`
+}
+
+// NeedsTypes reports whether any spec of cfg restricts symbols or exports,
+// or cfg checks hexagonal rules, which requires type information.
+// Alias and dot import rules need it too, to name packages and fix their references.
+func (cfg *Config) NeedsTypes() bool {
+	if len(cfg.Hexagonal) > 0 {
+		return true
+	}
+	for _, spec := range cfg.Specs {
+		if len(spec.Symbols.Forbid) > 0 || len(spec.Exports.Forbid) > 0 {
+			return true
+		}
+		if len(spec.Imports.Aliases) > 0 || spec.Imports.ForbidDot {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package config
+
+import "fmt"
+
+// Visibility restricts which packages may import a set of packages,
+// checked from the imported side rather than the importing side.
+type Visibility struct {
+	// Name identifies visibility violations, defaults to "visibility"
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Severity    Severity `yaml:"severity"`
+	// Packages are the packages whose visibility is restricted
+	Packages Packages `yaml:"packages"`
+	// VisibleTo lists glob patterns of the packages allowed to import them
+	VisibleTo []string `yaml:"visible_to"`
+}
+
+func (v *Visibility) validate() error {
+	if v.Name == "" {
+		v.Name = "visibility"
+	}
+	if v.Severity == "" {
+		v.Severity = SeverityError
+	}
+	if len(v.Packages.Include) == 0 {
+		return fmt.Errorf("visibility '%s' must specify 'packages'", v.Name)
+	}
+	return nil
+}
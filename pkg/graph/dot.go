@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT renders the graph in Graphviz DOT, drawing violating imports as red dashed edges.
+func WriteDOT(w io.Writer, g *Graph) error {
+	var sb strings.Builder
+	sb.WriteString("digraph \"arch-lint\" {\n")
+	sb.WriteString("  node [shape=box];\n")
+	for i, group := range g.groupNames() {
+		fmt.Fprintf(&sb, "  subgraph \"cluster_%d\" {\n", i)
+		fmt.Fprintf(&sb, "    label=%s;\n", strconv.Quote(group))
+		for _, node := range g.Nodes {
+			if g.Groups[node] == group {
+				fmt.Fprintf(&sb, "    %s;\n", strconv.Quote(node))
+			}
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, node := range g.Nodes {
+		if g.Groups[node] == "" {
+			fmt.Fprintf(&sb, "  %s;\n", strconv.Quote(node))
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s", strconv.Quote(e.From), strconv.Quote(e.To))
+		if e.Violating() {
+			fmt.Fprintf(&sb, " [color=red, style=dashed, label=%s]", strconv.Quote(strings.Join(e.Rules, "\n")))
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
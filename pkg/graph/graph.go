@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+)
+
+// Graph is the import graph of the packages in a module.
+type Graph struct {
+	// Nodes lists the packages, sorted
+	Nodes []string
+	// Edges lists the imports between packages, sorted
+	Edges []Edge
+	// Groups maps a package to the group it is drawn in, if any
+	Groups map[string]string
+}
+
+// Edge is an import of To by From.
+type Edge struct {
+	From string
+	To   string
+	// Rules lists the specs violated by the import, if any
+	Rules []string
+}
+
+// Violating reports whether the import violates a spec.
+func (e Edge) Violating() bool {
+	return len(e.Rules) > 0
+}
+
+// New builds the graph of the packages scanned in result, marking violating imports.
+func New(result *linter.Result) *Graph {
+	g := &Graph{Nodes: slices.Clone(result.Packages), Groups: make(map[string]string)}
+	for _, from := range result.Packages {
+		for _, to := range result.Imports[from] {
+			e := Edge{From: from, To: to}
+			for _, v := range result.Violations {
+				if v.Package == from && v.Import == to && !slices.Contains(e.Rules, v.Rule) {
+					e.Rules = append(e.Rules, v.Rule)
+				}
+			}
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	g.sort()
+	return g
+}
+
+// GroupBySpec groups each package under the first spec selecting it.
+func (g *Graph) GroupBySpec(specs []config.Spec) {
+	for _, node := range g.Nodes {
+		for _, spec := range specs {
+			if linter.Selects(spec, node) {
+				g.Groups[node] = spec.Name
+				break
+			}
+		}
+	}
+}
+
+// Collapse merges packages into their ancestor at the given depth, counted in path segments.
+// Imports within a merged package are dropped. A depth of zero or less leaves the graph unchanged.
+func (g *Graph) Collapse(depth int) *Graph {
+	if depth <= 0 {
+		return g
+	}
+	name := func(pkg string) string {
+		segments := strings.Split(pkg, "/")
+		if len(segments) <= depth {
+			return pkg
+		}
+		return strings.Join(segments[:depth], "/")
+	}
+
+	collapsed := &Graph{Groups: make(map[string]string)}
+	groups := make(map[string][]string)
+	for _, node := range g.Nodes {
+		n := name(node)
+		if !slices.Contains(collapsed.Nodes, n) {
+			collapsed.Nodes = append(collapsed.Nodes, n)
+		}
+		groups[n] = append(groups[n], g.Groups[node])
+	}
+	// A merged package keeps its group only if all of its packages share it
+	for n, members := range groups {
+		shared := members[0]
+		for _, m := range members[1:] {
+			if m != shared {
+				shared = ""
+				break
+			}
+		}
+		if shared != "" {
+			collapsed.Groups[n] = shared
+		}
+	}
+
+	edges := make(map[[2]string]*Edge)
+	for _, e := range g.Edges {
+		from, to := name(e.From), name(e.To)
+		if from == to {
+			continue
+		}
+		merged, ok := edges[[2]string{from, to}]
+		if !ok {
+			merged = &Edge{From: from, To: to}
+			edges[[2]string{from, to}] = merged
+		}
+		for _, rule := range e.Rules {
+			if !slices.Contains(merged.Rules, rule) {
+				merged.Rules = append(merged.Rules, rule)
+			}
+		}
+	}
+	for _, e := range edges {
+		collapsed.Edges = append(collapsed.Edges, *e)
+	}
+	collapsed.sort()
+	return collapsed
+}
+
+func (g *Graph) sort() {
+	slices.Sort(g.Nodes)
+	for i := range g.Edges {
+		slices.Sort(g.Edges[i].Rules)
+	}
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+}
+
+// ids assigns each node a short identifier, for formats that cannot use package paths.
+func (g *Graph) ids() map[string]string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node] = "n" + strconv.Itoa(i)
+	}
+	return ids
+}
+
+// groupNames returns the names of the groups in use, sorted.
+func (g *Graph) groupNames() []string {
+	var names []string
+	for _, group := range g.Groups {
+		if group != "" && !slices.Contains(names, group) {
+			names = append(names, group)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Filter keeps only the packages matching one of the glob patterns, and the imports between them.
+func (g *Graph) Filter(patterns []string) *Graph {
+	if len(patterns) == 0 {
+		return g
+	}
+	filtered := &Graph{Groups: make(map[string]string)}
+	for _, node := range g.Nodes {
+		for _, pattern := range patterns {
+			if ok, _ := doublestar.Match(pattern, node); ok {
+				filtered.Nodes = append(filtered.Nodes, node)
+				filtered.Groups[node] = g.Groups[node]
+				break
+			}
+		}
+	}
+	for _, e := range g.Edges {
+		if slices.Contains(filtered.Nodes, e.From) && slices.Contains(filtered.Nodes, e.To) {
+			filtered.Edges = append(filtered.Edges, e)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+var result = &linter.Result{
+	Packages: []string{"app/api", "app/books", "app/books/utils", "lib"},
+	Imports: map[string][]string{
+		"app/api":         {"app/books"},
+		"app/books/utils": {"app/books", "lib"},
+	},
+	Violations: []linter.Violation{{Rule: "no self", Package: "app/books/utils", Import: "app/books"}},
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	g := New(result)
+	testutil.Equals(t, g.Nodes, result.Packages)
+	testutil.Equals(t, g.Edges, []Edge{
+		{From: "app/api", To: "app/books"},
+		{From: "app/books/utils", To: "app/books", Rules: []string{"no self"}},
+		{From: "app/books/utils", To: "lib"},
+	})
+}
+
+func TestGraph_Collapse(t *testing.T) {
+	t.Parallel()
+	g := New(result)
+	g.GroupBySpec([]config.Spec{{Name: "app", Packages: config.Packages{Include: []string{"app/**"}}}})
+
+	got := g.Collapse(2)
+	testutil.Equals(t, got.Nodes, []string{"app/api", "app/books", "lib"})
+	testutil.Equals(t, got.Edges, []Edge{
+		{From: "app/api", To: "app/books"},
+		{From: "app/books", To: "lib"},
+	})
+	testutil.Equals(t, got.Groups, map[string]string{"app/api": "app", "app/books": "app"})
+
+	got = g.Collapse(1)
+	testutil.Equals(t, got.Nodes, []string{"app", "lib"})
+	testutil.Equals(t, got.Edges, []Edge{{From: "app", To: "lib"}})
+}
+
+func TestGraph_Filter(t *testing.T) {
+	t.Parallel()
+	got := New(result).Filter([]string{"app/**"})
+	testutil.Equals(t, got.Nodes, []string{"app/api", "app/books", "app/books/utils"})
+	testutil.Equals(t, len(got.Edges), 2)
+}
+
+func TestWriteMermaid(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteMermaid(&buf, New(result).Filter([]string{"app/**"})), nil)
+	testutil.Equals(t, buf.String(), `graph TD
+    n0["app/api"]
+    n1["app/books"]
+    n2["app/books/utils"]
+    n0 --> n1
+    n2 -.->|"❌ no self"| n1
+    linkStyle 1 stroke:red,stroke-width:2px
+`)
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+	g := New(result).Filter([]string{"app/books/**"})
+	g.GroupBySpec([]config.Spec{{Name: "books", Packages: config.Packages{Include: []string{"app/books"}}}})
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteDOT(&buf, g), nil)
+	testutil.Equals(t, buf.String(), `digraph "arch-lint" {
+  node [shape=box];
+  subgraph "cluster_0" {
+    label="books";
+    "app/books";
+  }
+  "app/books/utils";
+  "app/books/utils" -> "app/books" [color=red, style=dashed, label="no self"];
+}
+`)
+}
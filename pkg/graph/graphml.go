@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML renders the graph as GraphML.
+// Nodes carry their package and group, edges whether they violate a spec and which.
+func WriteGraphML(w io.Writer, g *Graph) error {
+	ids := g.ids()
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "package", For: "node", Name: "package", Type: "string"},
+			{ID: "group", For: "node", Name: "group", Type: "string"},
+			{ID: "violation", For: "edge", Name: "violation", Type: "boolean"},
+			{ID: "rules", For: "edge", Name: "rules", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "arch-lint", EdgeDefault: "directed"},
+	}
+	for _, node := range g.Nodes {
+		n := graphMLNode{ID: ids[node], Data: []graphMLData{{Key: "package", Value: node}}}
+		if group := g.Groups[node]; group != "" {
+			n.Data = append(n.Data, graphMLData{Key: "group", Value: group})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, n)
+	}
+	for _, e := range g.Edges {
+		edge := graphMLEdge{Source: ids[e.From], Target: ids[e.To]}
+		if e.Violating() {
+			edge.Data = []graphMLData{
+				{Key: "violation", Value: "true"},
+				{Key: "rules", Value: strings.Join(e.Rules, ", ")},
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteMermaid renders the graph as a Mermaid flowchart, drawing violating imports as red dotted links.
+func WriteMermaid(w io.Writer, g *Graph) error {
+	ids := g.ids()
+	var sb strings.Builder
+	sb.WriteString("graph TD\n")
+	for i, group := range g.groupNames() {
+		fmt.Fprintf(&sb, "    subgraph g%d[\"%s\"]\n", i, mermaidEscape(group))
+		for _, node := range g.Nodes {
+			if g.Groups[node] == group {
+				fmt.Fprintf(&sb, "        %s[\"%s\"]\n", ids[node], mermaidEscape(node))
+			}
+		}
+		sb.WriteString("    end\n")
+	}
+	for _, node := range g.Nodes {
+		if g.Groups[node] == "" {
+			fmt.Fprintf(&sb, "    %s[\"%s\"]\n", ids[node], mermaidEscape(node))
+		}
+	}
+
+	var violating []string
+	for i, e := range g.Edges {
+		if e.Violating() {
+			fmt.Fprintf(&sb, "    %s -.->|\"❌ %s\"| %s\n", ids[e.From], mermaidEscape(strings.Join(e.Rules, ", ")), ids[e.To])
+			violating = append(violating, strconv.Itoa(i))
+			continue
+		}
+		fmt.Fprintf(&sb, "    %s --> %s\n", ids[e.From], ids[e.To])
+	}
+	if len(violating) > 0 {
+		fmt.Fprintf(&sb, "    linkStyle %s stroke:red,stroke-width:2px\n", strings.Join(violating, ","))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -1,38 +1,291 @@
 package linter
 
-import "github.com/TheFellow/arch-lint/pkg/config"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Selects reports whether spec applies to pkg, that is pkg matches
+// one of the include patterns and none of the exclude patterns.
+func Selects(spec config.Spec, pkg string) bool {
+	return selects(spec.Packages, pkg)
+}
+
+func selects(packages config.Packages, pkg string) bool {
+	return matchesAny(packages.Include, pkg) && !matchesAny(packages.Exclude, pkg)
+}
+
+// Applies returns the names of the rules of cfg that apply to pkg
+func Applies(cfg *config.Config, pkg string) []string {
+	var rules []string
+	for _, spec := range cfg.Specs {
+		if Selects(spec, pkg) {
+			rules = append(rules, spec.Name)
+		}
+	}
+	if cfg.Layers != nil && LayerOf(cfg.Layers, pkg) >= 0 {
+		rules = append(rules, cfg.Layers.Name)
+	}
+	if cfg.Components != nil && selects(cfg.Components.Packages, pkg) {
+		rules = append(rules, cfg.Components.Name)
+	}
+	for _, cycles := range cfg.Cycles {
+		if _, ok := CycleGroup(cfg, cycles, pkg); ok {
+			rules = append(rules, cycles.Name)
+		}
+	}
+	for _, visibility := range cfg.Visibility {
+		if selects(visibility.Packages, pkg) {
+			rules = append(rules, visibility.Name)
+		}
+	}
+	for _, facade := range cfg.Facades {
+		if _, ok := MatchPattern(facade.Root+"/**", pkg); ok {
+			rules = append(rules, facade.Name)
+		}
+	}
+	for _, hex := range cfg.Hexagonal {
+		if matchesAny(hex.Adapters, pkg) || matchesAny(hex.Domain, pkg) {
+			rules = append(rules, hex.Name)
+		}
+	}
+	for _, d := range cfg.Deprecate {
+		if _, ok := deprecated(d, pkg); !ok {
+			rules = append(rules, d.Name)
+		}
+	}
+	if cfg.DeprecatedDocs != nil {
+		rules = append(rules, cfg.DeprecatedDocs.Name)
+	}
+	return rules
+}
+
+// GraphRules returns the names of the rules of cfg evaluated over the whole import graph,
+// which a single package cannot check: cycles, transitive specs and label specs
+func GraphRules(cfg *config.Config) []string {
+	var rules []string
+	for _, spec := range cfg.Specs {
+		if spec.Rules.Transitive || len(spec.Labels.Forbid) > 0 {
+			rules = append(rules, spec.Name)
+		}
+	}
+	for _, cycles := range cfg.Cycles {
+		rules = append(rules, cycles.Name)
+	}
+	return rules
+}
+
+// Check evaluates every import rule of cfg for currentPkg importing importedPkg,
+// returning a violation per rule broken
+func Check(cfg *config.Config, currentPkg, importedPkg string) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckImport(spec, currentPkg, importedPkg); v != nil {
+			v.Expired = ExpiredFor(spec, currentPkg, importedPkg)
+			violations = append(violations, *v)
+		}
+	}
+	if v := CheckLayers(cfg.Layers, currentPkg, importedPkg); v != nil {
+		violations = append(violations, *v)
+	}
+	if v := CheckComponents(cfg.Components, currentPkg, importedPkg); v != nil {
+		violations = append(violations, *v)
+	}
+	for _, visibility := range cfg.Visibility {
+		if v := CheckVisibility(visibility, currentPkg, importedPkg); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	for _, facade := range cfg.Facades {
+		if v := CheckFacade(facade, currentPkg, importedPkg); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	for _, d := range cfg.Deprecate {
+		if v := CheckDeprecation(d, currentPkg, importedPkg); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
 
 // CheckImport evaluates whether importedPkg is forbidden for currentPkg
 // under the given spec's rules. Returns a *Violation if forbidden, nil otherwise.
+// Expired exceptions are ignored.
 func CheckImport(spec config.Spec, currentPkg, importedPkg string) *Violation {
-	var capturedVars map[string]string
-	forbidden := false
-	for _, pat := range spec.Rules.Forbid {
-		if vars, ok := MatchPattern(pat, importedPkg); ok {
-			capturedVars = vars
-			forbidden = true
-			break
+	return checkImport(spec, currentPkg, importedPkg, nil)
+}
+
+// checkImport implements CheckImport, recording each step in trace if non-nil
+func checkImport(spec config.Spec, currentPkg, importedPkg string, trace *Explanation) *Violation {
+	forbid, capturedVars, forbidden := forbids(spec, importedPkg)
+	if trace != nil {
+		trace.Forbid, trace.Vars = forbid, capturedVars
+	}
+	var notAllowed bool
+	if !forbidden && len(spec.Rules.Allow) > 0 {
+		allow, ok := allows(spec, currentPkg, importedPkg)
+		notAllowed = !ok
+		if trace != nil {
+			trace.Allow, trace.NotAllowed = allow, notAllowed
 		}
 	}
-	if !forbidden {
+	if !forbidden && !notAllowed {
 		return nil
 	}
 
-	for _, pat := range spec.Rules.Except {
-		if ExceptRegex(pat, currentPkg, capturedVars) {
+	now := time.Now()
+	try := func(kind string, exc config.Exception, path string) bool {
+		if exc.Expired(now) {
+			trace.try(kind, exc, path, false, fmt.Sprintf("expired on %s", exc.ExpiresOn()))
+			return false
+		}
+		ok, why := exceptMatch(exc.Pattern, path, capturedVars)
+		trace.try(kind, exc, path, ok, why)
+		return ok
+	}
+
+	for _, exc := range spec.Rules.Except {
+		if try("except", exc, currentPkg) {
 			return nil
 		}
 	}
 
-	for _, pat := range spec.Rules.Exempt {
-		if ExceptRegex(pat, importedPkg, capturedVars) {
+	for _, exc := range spec.Rules.Exempt {
+		if try("exempt", exc, importedPkg) {
 			return nil
 		}
 	}
 
-	return &Violation{
-		Rule:    spec.Name,
-		Package: currentPkg,
-		Import:  importedPkg,
+	v := &Violation{
+		Rule:     spec.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: spec.Severity,
+	}
+	if notAllowed {
+		v.Reason = "not in allow-list"
+	}
+	if trace != nil {
+		trace.Violation = v
+	}
+	return v
+}
+
+// forbids returns the first forbid pattern matching importedPkg and the variables it captured
+func forbids(spec config.Spec, importedPkg string) (string, map[string]string, bool) {
+	for _, pat := range spec.Rules.Forbid {
+		if vars, ok := MatchPattern(pat, importedPkg); ok {
+			return pat, vars, true
+		}
+	}
+	return "", nil, false
+}
+
+// allows returns the first allow pattern matching importedPkg.
+// Variables of the pattern are captured from currentPkg when it matches the pattern too,
+// so "app/{feature}/**" only allows the importer's own feature and "app/{!feature}/**" only the others.
+func allows(spec config.Spec, currentPkg, importedPkg string) (string, bool) {
+	for _, pat := range spec.Rules.Allow {
+		positive := strings.ReplaceAll(pat, "{!", "{")
+		match := pat
+		vars, ok := MatchPattern(positive, currentPkg)
+		if !ok {
+			match, vars = positive, nil
+		}
+		if ExceptRegex(match, importedPkg, vars) {
+			return pat, true
+		}
+	}
+	return "", false
+}
+
+// notInAllowList reports whether spec has an allow-list that importedPkg is not in
+func notInAllowList(spec config.Spec, currentPkg, importedPkg string) bool {
+	if len(spec.Rules.Allow) == 0 {
+		return false
+	}
+	_, ok := allows(spec, currentPkg, importedPkg)
+	return !ok
+}
+
+// Expiry is an except or exempt entry of a spec that has expired
+type Expiry struct {
+	Rule      string
+	Severity  config.Severity
+	Kind      string
+	Exception config.Exception
+}
+
+func (e Expiry) String() string {
+	s := fmt.Sprintf("[%s] exception expired: %s %q expired on %s", e.Rule, e.Kind, e.Exception.Pattern, e.Exception.ExpiresOn())
+	if e.Exception.Ticket != "" {
+		s += fmt.Sprintf(" (ticket %s)", e.Exception.Ticket)
+	}
+	return s
+}
+
+// Expired returns every expired exception of the specs in cfg,
+// whether it applies to imports, symbols, exports or constructs
+func Expired(cfg *config.Config) []Expiry {
+	now := time.Now()
+	var expired []Expiry
+	for _, spec := range cfg.Specs {
+		lists := []struct {
+			kind       string
+			exceptions []config.Exception
+		}{
+			{"except", spec.Rules.Except},
+			{"exempt", spec.Rules.Exempt},
+			{"symbols except", spec.Symbols.Except},
+			{"symbols exempt", spec.Symbols.Exempt},
+			{"exports except", spec.Exports.Except},
+			{"exports exempt", spec.Exports.Exempt},
+			{"constructs except", spec.Constructs.Except},
+		}
+		for _, list := range lists {
+			for _, exc := range list.exceptions {
+				if exc.Expired(now) {
+					expired = append(expired, Expiry{Rule: spec.Name, Severity: spec.Severity, Kind: list.kind, Exception: exc})
+				}
+			}
+		}
+	}
+	if cfg.DeprecatedDocs != nil {
+		for _, exc := range cfg.DeprecatedDocs.Except {
+			if exc.Expired(now) {
+				expired = append(expired, Expiry{Rule: cfg.DeprecatedDocs.Name, Severity: cfg.DeprecatedDocs.Severity, Kind: "except", Exception: exc})
+			}
+		}
+	}
+	return expired
+}
+
+// ExpiredFor returns the expired exceptions of spec that would otherwise
+// allow currentPkg to import importedPkg
+func ExpiredFor(spec config.Spec, currentPkg, importedPkg string) []Expiry {
+	_, capturedVars, forbidden := forbids(spec, importedPkg)
+	if !forbidden && !notInAllowList(spec, currentPkg, importedPkg) {
+		return nil
+	}
+
+	now := time.Now()
+	var expired []Expiry
+	for _, exc := range spec.Rules.Except {
+		if exc.Expired(now) && ExceptRegex(exc.Pattern, currentPkg, capturedVars) {
+			expired = append(expired, Expiry{Rule: spec.Name, Severity: spec.Severity, Kind: "except", Exception: exc})
+		}
+	}
+	for _, exc := range spec.Rules.Exempt {
+		if exc.Expired(now) && ExceptRegex(exc.Pattern, importedPkg, capturedVars) {
+			expired = append(expired, Expiry{Rule: spec.Name, Severity: spec.Severity, Kind: "exempt", Exception: exc})
+		}
 	}
+	return expired
 }
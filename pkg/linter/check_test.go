@@ -0,0 +1,125 @@
+package linter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestCheckImport_ExpiredException(t *testing.T) {
+	t.Parallel()
+	expired := config.Exception{Pattern: "app/legacy", Ticket: "ARCH-1", Expires: time.Date(2000, 1, 31, 0, 0, 0, 0, time.Local)}
+	active := config.Exception{Pattern: "app/current", Expires: time.Date(2999, 12, 31, 0, 0, 0, 0, time.Local)}
+	spec := config.Spec{
+		Name:     "no-db",
+		Severity: config.SeverityWarning,
+		Packages: config.Packages{Include: []string{"app/**"}},
+		Rules: config.Rules{
+			Forbid: []string{"db"},
+			Except: []config.Exception{expired, active},
+		},
+	}
+
+	testutil.Equals(t, CheckImport(spec, "app/current", "db"), (*Violation)(nil))
+	testutil.Equals(t, ExpiredFor(spec, "app/current", "db"), []Expiry(nil))
+
+	testutil.Equals(t, CheckImport(spec, "app/legacy", "db"), &Violation{Rule: "no-db", Package: "app/legacy", Import: "db", Severity: config.SeverityWarning})
+	got := ExpiredFor(spec, "app/legacy", "db")
+	testutil.Equals(t, got, []Expiry{{Rule: "no-db", Severity: config.SeverityWarning, Kind: "except", Exception: expired}})
+	testutil.Equals(t, got[0].String(), `[no-db] exception expired: except "app/legacy" expired on 2000-01-31 (ticket ARCH-1)`)
+
+	testutil.Equals(t, Expired(&config.Config{Specs: []config.Spec{spec}}), got)
+}
+
+func TestExpired(t *testing.T) {
+	t.Parallel()
+	expired := config.Exception{Pattern: "app/legacy", Expires: time.Date(2000, 1, 31, 0, 0, 0, 0, time.Local)}
+	active := config.Exception{Pattern: "app/current", Expires: time.Date(2999, 12, 31, 0, 0, 0, 0, time.Local)}
+	tests := map[string]struct {
+		spec config.Spec
+		kind string
+	}{
+		"symbols except":    {config.Spec{Symbols: config.Symbols{Forbid: []string{"time.Now"}, Except: []config.Exception{expired, active}}}, "symbols except"},
+		"symbols exempt":    {config.Spec{Symbols: config.Symbols{Forbid: []string{"time.*"}, Exempt: []config.Exception{active, expired}}}, "symbols exempt"},
+		"exports except":    {config.Spec{Exports: config.Exports{Forbid: []string{"db/**"}, Except: []config.Exception{expired}}}, "exports except"},
+		"exports exempt":    {config.Spec{Exports: config.Exports{Forbid: []string{"db/**"}, Exempt: []config.Exception{expired}}}, "exports exempt"},
+		"constructs except": {config.Spec{Constructs: config.Constructs{Forbid: []string{config.ConstructGo}, Except: []config.Exception{expired, active}}}, "constructs except"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tt.spec.Name = "spec"
+			tt.spec.Severity = config.SeverityWarning
+			got := Expired(&config.Config{Specs: []config.Spec{tt.spec}})
+			testutil.Equals(t, got, []Expiry{{Rule: "spec", Severity: config.SeverityWarning, Kind: tt.kind, Exception: expired}})
+		})
+	}
+}
+
+func TestCheckImport_Allow(t *testing.T) {
+	t.Parallel()
+	spec := config.Spec{
+		Name:     "domain core",
+		Packages: config.Packages{Include: []string{"domain/**"}},
+		Rules: config.Rules{
+			Allow:  []string{"errors", "time", "domain/{feature}/**", "domain/shared/**"},
+			Exempt: []config.Exception{{Pattern: "fmt"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		allowed  bool
+	}{
+		{name: "stdlib", from: "domain/books", to: "errors", allowed: true},
+		{name: "same feature", from: "domain/books/model", to: "domain/books", allowed: true},
+		{name: "shared", from: "domain/books", to: "domain/shared/ids", allowed: true},
+		{name: "exempt", from: "domain/books", to: "fmt", allowed: true},
+		{name: "other feature", from: "domain/books", to: "domain/authors"},
+		{name: "not listed", from: "domain/books", to: "database/sql"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CheckImport(spec, tt.from, tt.to)
+			if tt.allowed {
+				testutil.Equals(t, got, (*Violation)(nil))
+				return
+			}
+			testutil.Equals(t, got, &Violation{Rule: "domain core", Package: tt.from, Import: tt.to, Reason: "not in allow-list"})
+			testutil.Equals(t, got.Diagnostic(), `[domain core] forbidden import of "`+tt.to+`": not in allow-list`)
+		})
+	}
+}
+
+// importCase is an import checked against a rule, forbidden with the given reason if set
+type importCase struct {
+	name     string
+	from, to string
+	reason   string
+}
+
+// checkImports runs check on every case in parallel, expecting an error violation of rule for each forbidden import
+func checkImports(t *testing.T, rule string, check func(from, to string) *Violation, tests []importCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := check(tt.from, tt.to)
+			if tt.reason == "" {
+				testutil.Equals(t, got, (*Violation)(nil))
+				return
+			}
+			testutil.Equals(t, got, &Violation{
+				Rule:     rule,
+				Package:  tt.from,
+				Import:   tt.to,
+				Severity: config.SeverityError,
+				Reason:   tt.reason,
+			})
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// ComponentsOf returns the names of the components containing pkg
+func ComponentsOf(components *config.Components, pkg string) []string {
+	var names []string
+	for _, component := range components.List {
+		if matchesAny(component.Packages, pkg) {
+			names = append(names, component.Name)
+		}
+	}
+	return names
+}
+
+// CheckComponents evaluates whether the component of currentPkg may depend on the component of importedPkg.
+// Returns a *Violation naming both components if not, nil otherwise.
+// Packages outside the components scope or not in exactly one component are not checked,
+// see ComponentIssue.
+func CheckComponents(components *config.Components, currentPkg, importedPkg string) *Violation {
+	if components == nil || !selects(components.Packages, currentPkg) || !selects(components.Packages, importedPkg) {
+		return nil
+	}
+	from, to := ComponentsOf(components, currentPkg), ComponentsOf(components, importedPkg)
+	if len(from) != 1 || len(to) != 1 || from[0] == to[0] {
+		return nil
+	}
+
+	i := slices.IndexFunc(components.List, func(c config.Component) bool { return c.Name == from[0] })
+	if slices.Contains(components.List[i].DependsOn, to[0]) {
+		return nil
+	}
+	return &Violation{
+		Rule:     components.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: components.Severity,
+		Reason:   fmt.Sprintf("component %q may not depend on %q", from[0], to[0]),
+	}
+}
+
+// ComponentIssue describes why pkg is misconfigured, if it is in the components scope
+// but belongs to no component or to more than one
+func ComponentIssue(components *config.Components, pkg string) (string, bool) {
+	if components == nil || !selects(components.Packages, pkg) {
+		return "", false
+	}
+	switch names := ComponentsOf(components, pkg); len(names) {
+	case 0:
+		return fmt.Sprintf("[%s] package %q belongs to no component", components.Name, pkg), true
+	case 1:
+		return "", false
+	default:
+		return fmt.Sprintf("[%s] package %q belongs to more than one component: %s", components.Name, pkg, strings.Join(names, ", ")), true
+	}
+}
@@ -0,0 +1,53 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+var components = &config.Components{
+	Name:     "components",
+	Severity: config.SeverityError,
+	Packages: config.Packages{Include: []string{"app/**"}},
+	List: []config.Component{
+		{Name: "orders", Packages: []string{"app/orders/**"}, DependsOn: []string{"catalog"}},
+		{Name: "catalog", Packages: []string{"app/catalog/**", "app/shared/**"}},
+		{Name: "billing", Packages: []string{"app/billing/**", "app/shared/**"}},
+	},
+}
+
+func TestCheckComponents(t *testing.T) {
+	t.Parallel()
+	checkImports(t, "components", func(from, to string) *Violation { return CheckComponents(components, from, to) }, []importCase{
+		{name: "allowed dependency", from: "app/orders", to: "app/catalog/products"},
+		{name: "same component", from: "app/orders/api", to: "app/orders"},
+		{name: "nested packages of one component", from: "app/orders/api/v2", to: "app/orders/internal/store"},
+		{name: "outside scope", from: "app/orders", to: "lib/log"},
+		{name: "in two components", from: "app/orders", to: "app/shared"},
+		{name: "in no component", from: "app/orders", to: "app/ordersarchive"},
+		{name: "undeclared dependency", from: "app/orders", to: "app/billing",
+			reason: `component "orders" may not depend on "billing"`},
+		{name: "undeclared dependency of a nested package", from: "app/orders/internal/store", to: "app/billing/invoices",
+			reason: `component "orders" may not depend on "billing"`},
+		{name: "reverse dependency", from: "app/catalog", to: "app/orders",
+			reason: `component "catalog" may not depend on "orders"`},
+	})
+}
+
+func TestComponentIssue(t *testing.T) {
+	t.Parallel()
+	_, ok := ComponentIssue(components, "app/orders")
+	testutil.Equals(t, ok, false)
+	_, ok = ComponentIssue(components, "lib/log")
+	testutil.Equals(t, ok, false)
+
+	msg, ok := ComponentIssue(components, "app/misc")
+	testutil.Equals(t, ok, true)
+	testutil.Equals(t, msg, `[components] package "app/misc" belongs to no component`)
+
+	msg, ok = ComponentIssue(components, "app/shared")
+	testutil.Equals(t, ok, true)
+	testutil.Equals(t, msg, `[components] package "app/shared" belongs to more than one component: catalog, billing`)
+}
@@ -0,0 +1,136 @@
+package linter
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Construct is an occurrence of a language construct that specs may forbid
+type Construct struct {
+	Pos token.Pos
+	// Kind is one of the config.Construct* names
+	Kind string
+	// Name identifies the occurrence within its package independently of its position:
+	// the variable, the file of an init function, the function of a go statement,
+	// or the arguments of a directive
+	Name string
+	// Description names the occurrence, such as "exported variable Default"
+	Description string
+}
+
+// Symbol identifies the construct in a violation, such as "go Publish"
+func (c Construct) Symbol() string {
+	if c.Name == "" {
+		return c.Kind
+	}
+	return c.Kind + " " + c.Name
+}
+
+// Constructs lists the checkable language constructs of files, in source order
+func Constructs(fset *token.FileSet, files []*ast.File) []Construct {
+	var constructs []Construct
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			name := ""
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name = funcName(decl)
+				if decl.Recv == nil && decl.Name.Name == "init" {
+					file := filepath.Base(fset.Position(decl.Pos()).Filename)
+					constructs = append(constructs, Construct{Pos: decl.Pos(), Kind: config.ConstructInit, Name: file, Description: "init function"})
+				}
+			case *ast.GenDecl:
+				if decl.Tok != token.VAR {
+					break
+				}
+				for _, spec := range decl.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if name.IsExported() {
+							constructs = append(constructs, Construct{Pos: name.Pos(), Kind: config.ConstructExportedVar, Name: name.Name, Description: "exported variable " + name.Name})
+						}
+					}
+				}
+			}
+			ast.Inspect(decl, func(n ast.Node) bool {
+				if stmt, ok := n.(*ast.GoStmt); ok {
+					constructs = append(constructs, Construct{Pos: stmt.Pos(), Kind: config.ConstructGo, Name: name, Description: "go statement"})
+				}
+				return true
+			})
+		}
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				if args, ok := strings.CutPrefix(c.Text, "//go:linkname "); ok {
+					constructs = append(constructs, Construct{Pos: c.Pos(), Kind: config.ConstructLinkname, Name: strings.Join(strings.Fields(args), " "), Description: "//go:linkname directive"})
+				} else if args, ok := strings.CutPrefix(c.Text, "//go:embed "); ok {
+					constructs = append(constructs, Construct{Pos: c.Pos(), Kind: config.ConstructEmbed, Name: strings.Join(strings.Fields(args), " "), Description: "//go:embed directive"})
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(constructs, func(a, b Construct) int { return int(a.Pos - b.Pos) })
+	return constructs
+}
+
+// funcName names a function declaration, qualifying methods by their receiver type
+func funcName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	recv := decl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch t := recv.(type) {
+	case *ast.IndexExpr:
+		recv = t.X
+	case *ast.IndexListExpr:
+		recv = t.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name + "." + decl.Name.Name
+	}
+	return decl.Name.Name
+}
+
+// CheckConstructs evaluates the construct rules of every spec of cfg selecting currentPkg,
+// returning a violation per spec broken
+func CheckConstructs(cfg *config.Config, currentPkg string, construct Construct) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckConstruct(spec, currentPkg, construct); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckConstruct evaluates whether currentPkg may use a construct under the construct rules of spec.
+// Except patterns match currentPkg. Returns a *Violation if forbidden, nil otherwise.
+func CheckConstruct(spec config.Spec, currentPkg string, construct Construct) *Violation {
+	if !slices.Contains(spec.Constructs.Forbid, construct.Kind) {
+		return nil
+	}
+	now := time.Now()
+	for _, exc := range spec.Constructs.Except {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, currentPkg, nil) {
+			return nil
+		}
+	}
+	return &Violation{
+		Rule:     spec.Name,
+		Package:  currentPkg,
+		Symbol:   construct.Symbol(),
+		Severity: spec.Severity,
+		Reason:   "forbidden " + construct.Description,
+	}
+}
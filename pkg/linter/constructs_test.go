@@ -0,0 +1,79 @@
+package linter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestConstructs(t *testing.T) {
+	t.Parallel()
+	src := `package events
+
+import _ "unsafe"
+
+var Default, fallback = "created", "none"
+
+func init() {}
+
+func (e Event) init() {}
+
+type Event struct{}
+
+func Publish(h func()) {
+	go h()
+	go func() {}()
+}
+
+func (e *Event) Send() {
+	go Publish(nil)
+}
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+//go:embed events.txt
+var names string
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "events.go", src, parser.ParseComments)
+	testutil.Equals(t, err, nil)
+
+	var got []string
+	for _, c := range Constructs(fset, []*ast.File{file}) {
+		got = append(got, fmt.Sprintf("%d %s: %s", fset.Position(c.Pos).Line, c.Symbol(), c.Description))
+	}
+	testutil.Equals(t, got, []string{
+		"5 exported-var Default: exported variable Default",
+		"7 init events.go: init function",
+		"14 go Publish: go statement",
+		"15 go Publish: go statement",
+		"19 go Event.Send: go statement",
+		"22 linkname nanotime runtime.nanotime: //go:linkname directive",
+		"25 embed events.txt: //go:embed directive",
+	})
+}
+
+func TestCheckConstruct(t *testing.T) {
+	t.Parallel()
+	spec := config.Spec{
+		Name:       "constructs",
+		Severity:   config.SeverityError,
+		Packages:   config.Packages{Include: []string{"**"}},
+		Constructs: config.Constructs{Forbid: []string{config.ConstructGo}, Except: []config.Exception{{Pattern: "worker/**"}}},
+	}
+	construct := Construct{Kind: config.ConstructGo, Name: "Publish", Description: "go statement"}
+
+	got := CheckConstruct(spec, "domain", construct)
+	testutil.Equals(t, got.Symbol, "go Publish")
+	testutil.Equals(t, got.Message(), `[constructs] package "domain": forbidden go statement`)
+	testutil.Equals(t, got.Diagnostic(), `[constructs] forbidden go statement`)
+
+	testutil.Equals(t, CheckConstruct(spec, "worker/pool", construct), (*Violation)(nil))
+	testutil.Equals(t, CheckConstruct(spec, "domain", Construct{Kind: config.ConstructInit, Description: "init function"}), (*Violation)(nil))
+}
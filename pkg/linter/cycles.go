@@ -0,0 +1,205 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// CycleGroup returns the group of pkg under the cycles rule, if it belongs to one
+func CycleGroup(cfg *config.Config, rule config.Cycles, pkg string) (string, bool) {
+	if rule.Components {
+		if cfg.Components == nil || !selects(cfg.Components.Packages, pkg) {
+			return "", false
+		}
+		if names := ComponentsOf(cfg.Components, pkg); len(names) == 1 {
+			return names[0], true
+		}
+		return "", false
+	}
+	vars, ok := MatchPattern(rule.Group, pkg)
+	if !ok {
+		return "", false
+	}
+	return groupName(rule.Group, vars), true
+}
+
+// groupName substitutes the captured variables into the group pattern, dropping a trailing /**
+func groupName(pattern string, vars map[string]string) string {
+	for name, value := range vars {
+		pattern = strings.ReplaceAll(pattern, "{"+name+"}", value)
+	}
+	return strings.TrimSuffix(pattern, "/**")
+}
+
+// packageEdge is an import of one package by another
+type packageEdge struct {
+	from, to string
+}
+
+// groupEdge aggregates the package imports from one group to another
+type groupEdge struct {
+	from, to string
+}
+
+// FindCycles reports every import between packages whose groups form a cycle under the cycles rules of cfg.
+// imports lists the packages imported by each package.
+// The imports of the group edges suggested for cutting say so in their reason.
+func FindCycles(cfg *config.Config, imports map[string][]string) []Violation {
+	var violations []Violation
+	for _, rule := range cfg.Cycles {
+		violations = append(violations, findCycles(cfg, rule, imports)...)
+	}
+	return violations
+}
+
+func findCycles(cfg *config.Config, rule config.Cycles, imports map[string][]string) []Violation {
+	edges := make(map[groupEdge][]packageEdge)
+	graph := make(map[string][]string)
+	for _, from := range slices.Sorted(maps.Keys(imports)) {
+		fromGroup, ok := CycleGroup(cfg, rule, from)
+		if !ok {
+			continue
+		}
+		for _, to := range imports[from] {
+			toGroup, ok := CycleGroup(cfg, rule, to)
+			if !ok || toGroup == fromGroup {
+				continue
+			}
+			e := groupEdge{fromGroup, toGroup}
+			if _, ok := edges[e]; !ok {
+				graph[fromGroup] = append(graph[fromGroup], toGroup)
+			}
+			edges[e] = append(edges[e], packageEdge{from, to})
+		}
+	}
+
+	var violations []Violation
+	for _, scc := range stronglyConnected(graph) {
+		if len(scc) < 2 {
+			continue
+		}
+		slices.Sort(scc)
+		quoted := make([]string, len(scc))
+		for i, group := range scc {
+			quoted[i] = fmt.Sprintf("%q", group)
+		}
+		reason := fmt.Sprintf("import cycle between groups %s", strings.Join(quoted, ", "))
+
+		var within []groupEdge
+		for e := range edges {
+			if slices.Contains(scc, e.from) && slices.Contains(scc, e.to) {
+				within = append(within, e)
+			}
+		}
+		cut := cutEdges(within, edges)
+		for _, e := range within {
+			for _, pe := range edges[e] {
+				v := Violation{
+					Rule:     rule.Name,
+					Package:  pe.from,
+					Import:   pe.to,
+					Severity: rule.Severity,
+					Reason:   reason,
+				}
+				if cut[e] {
+					v.Reason += fmt.Sprintf("; cut %q -> %q to break it", e.from, e.to)
+				}
+				violations = append(violations, v)
+			}
+		}
+	}
+	return violations
+}
+
+// cutEdges suggests group edges to cut so that the remaining edges are acyclic.
+// Edges carrying the most imports are kept first, so the suggested cuts are the cheap ones.
+func cutEdges(within []groupEdge, edges map[groupEdge][]packageEdge) map[groupEdge]bool {
+	slices.SortFunc(within, func(a, b groupEdge) int {
+		if n := len(edges[b]) - len(edges[a]); n != 0 {
+			return n
+		}
+		return strings.Compare(a.from+"\x00"+a.to, b.from+"\x00"+b.to)
+	})
+
+	kept := make(map[string][]string)
+	cut := make(map[groupEdge]bool)
+	for _, e := range within {
+		if reaches(kept, e.to, e.from) {
+			cut[e] = true
+			continue
+		}
+		kept[e.from] = append(kept[e.from], e.to)
+	}
+	return cut
+}
+
+// reaches reports whether to is reachable from from in graph
+func reaches(graph map[string][]string, from, to string) bool {
+	seen := make(map[string]bool)
+	stack := []string{from}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == to {
+			return true
+		}
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		stack = append(stack, graph[node]...)
+	}
+	return false
+}
+
+// stronglyConnected returns the strongly connected components of graph using Tarjan's algorithm
+func stronglyConnected(graph map[string][]string) [][]string {
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		sccs    [][]string
+		visit   func(string)
+	)
+	visit = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range graph[node] {
+			if _, ok := index[next]; !ok {
+				visit(next)
+				lowlink[node] = min(lowlink[node], lowlink[next])
+			} else if onStack[next] {
+				lowlink[node] = min(lowlink[node], index[next])
+			}
+		}
+
+		if lowlink[node] == index[node] {
+			var scc []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				scc = append(scc, top)
+				if top == node {
+					break
+				}
+			}
+			sccs = append(sccs, scc)
+		}
+	}
+
+	for _, node := range slices.Sorted(maps.Keys(graph)) {
+		if _, ok := index[node]; !ok {
+			visit(node)
+		}
+	}
+	return sccs
+}
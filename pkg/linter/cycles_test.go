@@ -0,0 +1,51 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestFindCycles(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Cycles: []config.Cycles{{Name: "cycles", Severity: config.SeverityError, Group: "app/{feature}/**"}},
+	}
+	imports := map[string][]string{
+		"app/books":         {"app/authors"},
+		"app/books/api":     {"app/authors", "app/books"},
+		"app/authors/books": {"app/books"},
+		"app/authors":       {"app/shared"},
+		"app/shared":        {"lib"},
+	}
+
+	reason := `import cycle between groups "app/authors", "app/books"`
+	cut := reason + `; cut "app/authors" -> "app/books" to break it`
+	testutil.Equals(t, FindCycles(cfg, imports), []Violation{
+		{Rule: "cycles", Package: "app/books", Import: "app/authors", Severity: config.SeverityError, Reason: reason},
+		{Rule: "cycles", Package: "app/books/api", Import: "app/authors", Severity: config.SeverityError, Reason: reason},
+		{Rule: "cycles", Package: "app/authors/books", Import: "app/books", Severity: config.SeverityError, Reason: cut},
+	})
+}
+
+func TestFindCycles_Components(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Components: components,
+		Cycles:     []config.Cycles{{Name: "cycles", Severity: config.SeverityError, Components: true}},
+	}
+	imports := map[string][]string{
+		"app/orders":  {"app/catalog"},
+		"app/catalog": {"app/billing"},
+		"app/billing": {"app/orders", "app/shared"},
+	}
+
+	got := FindCycles(cfg, imports)
+	testutil.Equals(t, len(got), 3)
+	reason := `import cycle between groups "billing", "catalog", "orders"`
+	testutil.Equals(t, got[0].Reason, reason)
+	testutil.Equals(t, got[1].Reason, reason)
+	testutil.Equals(t, got[2], Violation{Rule: "cycles", Package: "app/orders", Import: "app/catalog", Severity: config.SeverityError,
+		Reason: reason + `; cut "orders" -> "catalog" to break it`})
+}
@@ -0,0 +1,121 @@
+package linter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// CheckDeprecation evaluates whether importedPkg is the deprecated package of d, or one of its subpackages.
+// Packages of the deprecated tree may import each other.
+// Returns a *Violation naming the replacement if so, nil otherwise.
+func CheckDeprecation(d config.Deprecation, currentPkg, importedPkg string) *Violation {
+	suffix, ok := deprecated(d, importedPkg)
+	if !ok {
+		return nil
+	}
+	if _, ok := deprecated(d, currentPkg); ok {
+		return nil
+	}
+
+	v := &Violation{
+		Rule:     d.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: d.Severity,
+		Reason:   "deprecated",
+	}
+	if d.To != "" {
+		v.Reason += fmt.Sprintf(", use %q instead", d.To+suffix)
+	}
+	if d.Message != "" {
+		v.Reason += ": " + d.Message
+	}
+	switch {
+	case d.Overdue(time.Now()):
+		v.Severity = config.SeverityError
+		v.Reason += fmt.Sprintf(" (deadline %s passed)", d.Deadline)
+	case d.Deadline != "":
+		v.Reason += fmt.Sprintf(" (deadline %s)", d.Deadline)
+	}
+	if d.Compatible {
+		v.Replacement = d.To + suffix
+	}
+	return v
+}
+
+// deprecated reports whether pkg is From or one of its subpackages, returning the path below From
+func deprecated(d config.Deprecation, pkg string) (string, bool) {
+	suffix, ok := strings.CutPrefix(pkg, d.From)
+	if !ok || (suffix != "" && !strings.HasPrefix(suffix, "/")) {
+		return "", false
+	}
+	return suffix, true
+}
+
+// DeprecationNotice returns the "Deprecated:" paragraph of a package doc comment on one line,
+// or the empty string if the package is not deprecated
+func DeprecationNotice(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	for _, paragraph := range strings.Split(doc.Text(), "\n\n") {
+		if notice, ok := strings.CutPrefix(paragraph, "Deprecated: "); ok {
+			return strings.Join(strings.Fields(notice), " ")
+		}
+	}
+	return ""
+}
+
+// DirDeprecation returns the deprecation notice of the package in dir,
+// parsing only the package clause of its non-test files
+func DirDeprecation(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		if notice := DeprecationNotice(file.Doc); notice != "" {
+			return notice
+		}
+	}
+	return ""
+}
+
+// CheckDeprecatedDoc evaluates whether currentPkg may import importedPkg, documented as deprecated by notice.
+// Packages below importedPkg may import it, and except patterns match currentPkg.
+// Returns a *Violation showing the notice if forbidden, nil otherwise.
+func CheckDeprecatedDoc(d *config.DeprecatedDocs, currentPkg, importedPkg, notice string) *Violation {
+	if d == nil || notice == "" || currentPkg == importedPkg || strings.HasPrefix(currentPkg, importedPkg+"/") {
+		return nil
+	}
+	now := time.Now()
+	for _, exc := range d.Except {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, currentPkg, nil) {
+			return nil
+		}
+	}
+	return &Violation{
+		Rule:     d.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: d.Severity,
+		Reason:   "deprecated: " + notice,
+	}
+}
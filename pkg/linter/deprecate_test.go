@@ -0,0 +1,76 @@
+package linter
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestCheckDeprecation(t *testing.T) {
+	t.Parallel()
+	migration := config.Deprecation{Name: "log", Severity: config.SeverityWarning, From: "internal/oldlog", To: "internal/log", Message: "use the structured logger", Compatible: true}
+
+	got := CheckDeprecation(migration, "audit", "internal/oldlog/level")
+	testutil.Equals(t, got.Severity, config.SeverityWarning)
+	testutil.Equals(t, got.Replacement, "internal/log/level")
+	testutil.Equals(t, got.Message(), `[log] package "audit" imports "internal/oldlog/level": deprecated, use "internal/log/level" instead: use the structured logger`)
+
+	testutil.Equals(t, CheckDeprecation(migration, "audit", "internal/oldlogger"), (*Violation)(nil))
+	testutil.Equals(t, CheckDeprecation(migration, "internal/oldlog", "internal/oldlog/level"), (*Violation)(nil))
+}
+
+func TestCheckDeprecation_Deadline(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := dir + "/rules.yml"
+	os.WriteFile(path, []byte("deprecate:\n  - from: internal/oldmetrics\n    deadline: 2020-01-01\n  - from: internal/oldlog\n    deadline: 2999-12-31\n"), 0o644)
+	cfg, err := config.Load(path)
+	testutil.Equals(t, err, nil)
+
+	overdue := CheckDeprecation(cfg.Deprecate[0], "audit", "internal/oldmetrics")
+	testutil.Equals(t, overdue.Severity, config.SeverityError)
+	testutil.Equals(t, overdue.Reason, "deprecated (deadline 2020-01-01 passed)")
+	testutil.Equals(t, overdue.Replacement, "")
+
+	pending := CheckDeprecation(cfg.Deprecate[1], "audit", "internal/oldlog")
+	testutil.Equals(t, pending.Severity, config.SeverityWarning)
+	testutil.Equals(t, pending.Reason, "deprecated (deadline 2999-12-31)")
+}
+
+func TestDeprecationNotice(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "deprecated", src: "// Package old does things.\n//\n// Deprecated: use\n// package new instead.\npackage old\n", want: "use package new instead."},
+		{name: "not a paragraph", src: "// Package old is not Deprecated: at all.\npackage old\n"},
+		{name: "no doc", src: "package old\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file, err := parser.ParseFile(token.NewFileSet(), "old.go", tt.src, parser.PackageClauseOnly|parser.ParseComments)
+			testutil.Equals(t, err, nil)
+			testutil.Equals(t, DeprecationNotice(file.Doc), tt.want)
+		})
+	}
+}
+
+func TestCheckDeprecatedDoc(t *testing.T) {
+	t.Parallel()
+	docs := &config.DeprecatedDocs{Name: "deprecated", Severity: config.SeverityError, Except: []config.Exception{{Pattern: "cmd/**"}}}
+
+	got := CheckDeprecatedDoc(docs, "jobs", "legacy/settings", "use wiring instead.")
+	testutil.Equals(t, got.Message(), `[deprecated] package "jobs" imports "legacy/settings": deprecated: use wiring instead.`)
+
+	testutil.Equals(t, CheckDeprecatedDoc(docs, "cmd/server", "legacy/settings", "use wiring instead."), (*Violation)(nil))
+	testutil.Equals(t, CheckDeprecatedDoc(docs, "legacy/settings/env", "legacy/settings", "use wiring instead."), (*Violation)(nil))
+	testutil.Equals(t, CheckDeprecatedDoc(docs, "jobs", "wiring", ""), (*Violation)(nil))
+	testutil.Equals(t, CheckDeprecatedDoc(nil, "jobs", "legacy/settings", "use wiring instead."), (*Violation)(nil))
+}
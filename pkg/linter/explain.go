@@ -0,0 +1,116 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Explanation traces how a spec evaluates an import
+type Explanation struct {
+	Spec     config.Spec
+	Importer string
+	Imported string
+	// Forbid is the forbid pattern matching the imported package, if any
+	Forbid string
+	// Vars holds the variables captured by Forbid
+	Vars map[string]string
+	// Allow is the allow pattern matching the imported package, if any
+	Allow string
+	// NotAllowed is set when the imported package is not in the allow-list
+	NotAllowed bool
+	// Exceptions lists the except and exempt patterns tried, in order
+	Exceptions []ExceptionTrace
+	// Violation is the resulting violation, nil if the import is allowed
+	Violation *Violation
+}
+
+// ExceptionTrace records an except or exempt pattern tried against a package
+type ExceptionTrace struct {
+	Kind      string
+	Exception config.Exception
+	Path      string
+	Matched   bool
+	Reason    string
+}
+
+// try records an exception attempt, if tracing
+func (e *Explanation) try(kind string, exc config.Exception, path string, matched bool, reason string) {
+	if e == nil {
+		return
+	}
+	e.Exceptions = append(e.Exceptions, ExceptionTrace{
+		Kind:      kind,
+		Exception: exc,
+		Path:      path,
+		Matched:   matched,
+		Reason:    reason,
+	})
+}
+
+// Explain traces the evaluation of importer importing imported
+// for every spec selecting importer. Packages may be given with or without the module path.
+func Explain(cfg *config.Config, importer, imported string) []Explanation {
+	if moduleName, err := getModuleName(); err == nil {
+		importer = strings.TrimPrefix(importer, moduleName+"/")
+		imported = strings.TrimPrefix(imported, moduleName+"/")
+	}
+
+	var explanations []Explanation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, importer) {
+			continue
+		}
+		e := Explanation{Spec: spec, Importer: importer, Imported: imported}
+		checkImport(spec, importer, imported, &e)
+		explanations = append(explanations, e)
+	}
+	return explanations
+}
+
+func (e Explanation) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "spec: %s\n", e.Spec.Name)
+	fmt.Fprintf(&sb, "  selects %q\n", e.Importer)
+	switch {
+	case e.Forbid != "":
+		fmt.Fprintf(&sb, "  forbid %q matches %q", e.Forbid, e.Imported)
+		if len(e.Vars) > 0 {
+			var vars []string
+			for _, key := range slices.Sorted(maps.Keys(e.Vars)) {
+				vars = append(vars, fmt.Sprintf("{%s}=%s", key, e.Vars[key]))
+			}
+			fmt.Fprintf(&sb, " capturing %s", strings.Join(vars, ", "))
+		}
+		sb.WriteString("\n")
+	case e.NotAllowed:
+		fmt.Fprintf(&sb, "  %q is not in allow-list\n", e.Imported)
+	case e.Allow != "":
+		fmt.Fprintf(&sb, "  allow %q matches %q\n", e.Allow, e.Imported)
+		sb.WriteString("  result: allowed\n")
+		return sb.String()
+	default:
+		fmt.Fprintf(&sb, "  no forbid pattern matches %q\n", e.Imported)
+		sb.WriteString("  result: allowed\n")
+		return sb.String()
+	}
+
+	for _, t := range e.Exceptions {
+		verdict := "no match"
+		if t.Matched {
+			verdict = "match"
+		}
+		fmt.Fprintf(&sb, "  %s %q: %s: %s\n", t.Kind, t.Exception.Pattern, verdict, t.Reason)
+	}
+
+	switch {
+	case e.Violation != nil:
+		sb.WriteString("  result: forbidden\n")
+	default:
+		sb.WriteString("  result: allowed by exception\n")
+	}
+	return sb.String()
+}
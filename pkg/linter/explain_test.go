@@ -0,0 +1,70 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{Specs: []config.Spec{
+		{
+			Name:     "features",
+			Packages: config.Packages{Include: []string{"app/**"}},
+			Rules: config.Rules{
+				Forbid: []string{"app/{feature}/**"},
+				Except: []config.Exception{{Pattern: "api/**"}, {Pattern: "app/{!feature}/**"}},
+			},
+		},
+		{
+			Name:     "not selected",
+			Packages: config.Packages{Include: []string{"api/**"}},
+			Rules:    config.Rules{Forbid: []string{"**"}},
+		},
+	}}
+
+	got := Explain(cfg, "app/books/utils", "app/books")
+	testutil.Equals(t, len(got), 1)
+	testutil.Equals(t, got[0].Forbid, "app/{feature}/**")
+	testutil.Equals(t, got[0].Vars, map[string]string{"feature": "books"})
+	testutil.Equals(t, got[0].Exceptions, []ExceptionTrace{
+		{Kind: "except", Exception: config.Exception{Pattern: "api/**"}, Path: "app/books/utils",
+			Reason: `"app/books/utils" does not match the pattern`},
+		{Kind: "except", Exception: config.Exception{Pattern: "app/{!feature}/**"}, Path: "app/books/utils",
+			Reason: "negated variable {!feature} equals captured value books"},
+	})
+	testutil.Equals(t, got[0].Violation, CheckImport(cfg.Specs[0], "app/books/utils", "app/books"))
+	testutil.Equals(t, got[0].String(), `spec: features
+  selects "app/books/utils"
+  forbid "app/{feature}/**" matches "app/books" capturing {feature}=books
+  except "api/**": no match: "app/books/utils" does not match the pattern
+  except "app/{!feature}/**": no match: negated variable {!feature} equals captured value books
+  result: forbidden
+`)
+
+	got = Explain(cfg, "app/authors", "app/books")
+	testutil.Equals(t, got[0].Violation, (*Violation)(nil))
+	testutil.Equals(t, got[0].Exceptions[1].Matched, true)
+}
+
+func TestExplain_Allow(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{Specs: []config.Spec{{
+		Name:     "domain core",
+		Packages: config.Packages{Include: []string{"domain/**"}},
+		Rules:    config.Rules{Allow: []string{"errors", "domain/**"}},
+	}}}
+
+	testutil.Equals(t, Explain(cfg, "domain", "errors")[0].String(), `spec: domain core
+  selects "domain"
+  allow "errors" matches "errors"
+  result: allowed
+`)
+	testutil.Equals(t, Explain(cfg, "domain", "net/http")[0].String(), `spec: domain core
+  selects "domain"
+  "net/http" is not in allow-list
+  result: forbidden
+`)
+}
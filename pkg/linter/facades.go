@@ -0,0 +1,38 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// CheckFacade evaluates whether currentPkg imports importedPkg through the facade of its component.
+// Importers within the component may import any of its packages.
+// Returns a *Violation naming the facade to use if not, nil otherwise.
+func CheckFacade(facade config.Facade, currentPkg, importedPkg string) *Violation {
+	vars, ok := MatchPattern(facade.Root+"/**", importedPkg)
+	if !ok {
+		return nil
+	}
+	root := groupName(facade.Root, vars)
+	if importedPkg == root || currentPkg == root || strings.HasPrefix(currentPkg, root+"/") {
+		return nil
+	}
+	sub := strings.TrimPrefix(importedPkg, root+"/")
+	if matchesAny(facade.Public, sub) {
+		return nil
+	}
+
+	facades := []string{fmt.Sprintf("%q", root)}
+	for _, public := range facade.Public {
+		facades = append(facades, fmt.Sprintf("%q", root+"/"+public))
+	}
+	return &Violation{
+		Rule:     facade.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: facade.Severity,
+		Reason:   fmt.Sprintf("reaches into %q, import its facade %s instead", root, strings.Join(facades, " or ")),
+	}
+}
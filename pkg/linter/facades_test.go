@@ -0,0 +1,32 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+func TestCheckFacade(t *testing.T) {
+	t.Parallel()
+	facade := config.Facade{
+		Name:     "facades",
+		Severity: config.SeverityError,
+		Root:     "app/{feature}",
+		Public:   []string{"api", "events/**"},
+	}
+
+	reason := `reaches into "app/books", import its facade "app/books" or "app/books/api" or "app/books/events/**" instead`
+	checkImports(t, "facades", func(from, to string) *Violation { return CheckFacade(facade, from, to) }, []importCase{
+		{name: "root", from: "cmd", to: "app/books"},
+		{name: "public subpackage", from: "cmd", to: "app/books/api"},
+		{name: "public glob", from: "app/authors", to: "app/books/events/created"},
+		{name: "public glob root", from: "app/authors", to: "app/books/events"},
+		{name: "within component", from: "app/books/api", to: "app/books/internal/store"},
+		{name: "root within component", from: "app/books", to: "app/books/utils"},
+		{name: "outside roots", from: "cmd", to: "lib/log"},
+		{name: "reach through", from: "app/authors", to: "app/books/utils", reason: reason},
+		{name: "below a public subpackage", from: "cmd", to: "app/books/api/internal", reason: reason},
+		{name: "from a component sharing a prefix", from: "app/booksellers", to: "app/books/utils", reason: reason},
+		{name: "from a public package of another component", from: "app/authors/api", to: "app/books/utils", reason: reason},
+	})
+}
@@ -0,0 +1,122 @@
+package linter
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// PortInterfaces lists the non-empty interfaces declared by the port packages among pkgs and their dependencies.
+// moduleName is trimmed from the package paths before matching the ports patterns.
+func PortInterfaces(hex config.Hexagonal, moduleName string, pkgs []*types.Package) []*types.TypeName {
+	var ports []*types.TypeName
+	visited := make(map[*types.Package]bool)
+	var visit func(*types.Package)
+	visit = func(p *types.Package) {
+		if visited[p] {
+			return
+		}
+		visited[p] = true
+		if matchesAny(hex.Ports, strings.TrimPrefix(p.Path(), moduleName+"/")) {
+			scope := p.Scope()
+			for _, name := range scope.Names() {
+				obj, ok := scope.Lookup(name).(*types.TypeName)
+				if !ok || !obj.Exported() {
+					continue
+				}
+				if iface, ok := obj.Type().Underlying().(*types.Interface); ok && iface.NumMethods() > 0 {
+					ports = append(ports, obj)
+				}
+			}
+		}
+		for _, imp := range p.Imports() {
+			visit(imp)
+		}
+	}
+	for _, pkg := range pkgs {
+		visit(pkg)
+	}
+	return ports
+}
+
+// CheckAdapters evaluates the hexagonal rules of cfg for the adapter package currentPkg
+// against the ports among loaded and their dependencies, returning a violation per rule broken
+func CheckAdapters(cfg *config.Config, moduleName, currentPkg string, pkg *types.Package, loaded []*types.Package) []Violation {
+	var violations []Violation
+	for _, hex := range cfg.Hexagonal {
+		if v := CheckAdapter(hex, moduleName, currentPkg, pkg, loaded); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckAdapter evaluates whether the adapter package currentPkg declares an exported type,
+// or pointer to it, implementing a port interface declared among loaded and their dependencies.
+// Returns a *Violation if it implements none, nil otherwise, if currentPkg is not an adapter or if no port is loaded.
+func CheckAdapter(hex config.Hexagonal, moduleName, currentPkg string, pkg *types.Package, loaded []*types.Package) *Violation {
+	if pkg == nil || !matchesAny(hex.Adapters, currentPkg) {
+		return nil
+	}
+	ports := PortInterfaces(hex, moduleName, loaded)
+	if len(ports) == 0 {
+		return nil
+	}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() || obj.IsAlias() {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+			continue
+		}
+		for _, port := range ports {
+			iface := port.Type().Underlying().(*types.Interface)
+			if types.Implements(named, iface) || types.Implements(types.NewPointer(named), iface) {
+				return nil
+			}
+		}
+	}
+	return &Violation{
+		Rule:     hex.Name,
+		Package:  currentPkg,
+		Severity: hex.Severity,
+		Reason:   fmt.Sprintf("no exported type implements a port of %s", strings.Join(hex.Ports, ", ")),
+	}
+}
+
+// CheckDomainUses evaluates the hexagonal rules of cfg for a use of another package's object by currentPkg,
+// returning a violation per rule broken
+func CheckDomainUses(cfg *config.Config, moduleName, currentPkg string, use Use) []Violation {
+	var violations []Violation
+	for _, hex := range cfg.Hexagonal {
+		if v := CheckDomainUse(hex, moduleName, currentPkg, use); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckDomainUse evaluates whether the domain package currentPkg uses an object of an adapter package.
+// Returns a *Violation if it does, nil otherwise or if currentPkg is not part of the domain.
+func CheckDomainUse(hex config.Hexagonal, moduleName, currentPkg string, use Use) *Violation {
+	if !matchesAny(hex.Domain, currentPkg) {
+		return nil
+	}
+	pkg := strings.TrimPrefix(use.Package, moduleName+"/")
+	if !matchesAny(hex.Adapters, pkg) {
+		return nil
+	}
+	return &Violation{
+		Rule:     hex.Name,
+		Package:  currentPkg,
+		Import:   pkg,
+		Symbol:   pkg + "." + use.Name,
+		Severity: hex.Severity,
+		Reason:   fmt.Sprintf("domain depends on adapter %q, use a port interface instead", pkg),
+	}
+}
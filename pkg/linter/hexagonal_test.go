@@ -0,0 +1,118 @@
+package linter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+var hexagonal = config.Hexagonal{
+	Name:     "hex",
+	Severity: config.SeverityError,
+	Ports:    []string{"domain/ports/**"},
+	Adapters: []string{"adapters/**"},
+	Domain:   []string{"domain/**"},
+}
+
+// checkSource type-checks src as the package path, resolving imports from deps
+func checkSource(t *testing.T, path, src string, deps ...*types.Package) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path+".go", src, 0)
+	testutil.Equals(t, err, nil)
+	conf := types.Config{Importer: importerFunc(func(imp string) (*types.Package, error) {
+		for _, dep := range deps {
+			if dep.Path() == imp {
+				return dep, nil
+			}
+		}
+		t.Fatalf("unexpected import %q", imp)
+		return nil, nil
+	})}
+	pkg, err := conf.Check(path, fset, []*ast.File{file}, nil)
+	testutil.Equals(t, err, nil)
+	return pkg
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+func TestCheckAdapter(t *testing.T) {
+	t.Parallel()
+	ports := checkSource(t, "example.com/app/domain/ports", "package ports\n\ntype Store interface{ Save(string) error }\n\ntype Any interface{}\n")
+
+	tests := []struct {
+		name   string
+		path   string
+		src    string
+		loaded bool
+		reason string
+	}{
+		{
+			name:   "pointer receiver",
+			path:   "adapters/memory",
+			src:    "package memory\n\ntype Memory struct{}\n\nfunc (*Memory) Save(string) error { return nil }\n",
+			loaded: true,
+		},
+		{
+			name:   "wrong signature",
+			path:   "adapters/broken",
+			src:    "package broken\n\ntype Broken struct{}\n\nfunc (Broken) Save(string) {}\n",
+			loaded: true,
+			reason: "no exported type implements a port of domain/ports/**",
+		},
+		{
+			name:   "unexported",
+			path:   "adapters/hidden",
+			src:    "package hidden\n\ntype hidden struct{}\n\nfunc (hidden) Save(string) error { return nil }\n",
+			loaded: true,
+			reason: "no exported type implements a port of domain/ports/**",
+		},
+		{
+			name: "no port loaded",
+			path: "adapters/memory",
+			src:  "package memory\n\ntype Memory struct{}\n",
+		},
+		{
+			name: "not an adapter",
+			path: "infrastructure/db",
+			src:  "package db\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pkg := checkSource(t, "example.com/app/"+tt.path, tt.src)
+			// The ports are loaded alongside the adapter, which need not import them
+			loaded := []*types.Package{pkg}
+			if tt.loaded {
+				loaded = append(loaded, ports)
+			}
+			got := CheckAdapter(hexagonal, "example.com/app", tt.path, pkg, loaded)
+			if tt.reason == "" {
+				testutil.Equals(t, got, (*Violation)(nil))
+				return
+			}
+			testutil.Equals(t, got.Reason, tt.reason)
+			testutil.Equals(t, got.Message(), `[hex] package "`+tt.path+`": `+tt.reason)
+			testutil.Equals(t, got.Diagnostic(), `[hex] `+tt.reason)
+		})
+	}
+}
+
+func TestCheckDomainUse(t *testing.T) {
+	t.Parallel()
+	use := Use{Package: "example.com/app/adapters/memory", Name: "Memory"}
+
+	got := CheckDomainUse(hexagonal, "example.com/app", "domain/orders", use)
+	testutil.Equals(t, got.Message(), `[hex] package "domain/orders" uses "adapters/memory.Memory": domain depends on adapter "adapters/memory", use a port interface instead`)
+
+	testutil.Equals(t, CheckDomainUse(hexagonal, "example.com/app", "cmd/server", use), (*Violation)(nil))
+	testutil.Equals(t, CheckDomainUse(hexagonal, "example.com/app", "domain/orders", Use{Package: "example.com/app/domain/ports", Name: "Store"}), (*Violation)(nil))
+}
@@ -0,0 +1,132 @@
+package linter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// ImportForm is how a file imports a package
+type ImportForm struct {
+	// Path is the imported package, relative to the module
+	Path string
+	// Name is the explicit name of the import, "." or "_", empty if none
+	Name string
+	// Package is the name declared by the imported package, empty if not type-checked
+	Package string
+	// Test is set for the imports of test files
+	Test bool
+}
+
+// FormOf returns the form of imp in the file named filename, trimming moduleName from the imported path
+func FormOf(imp *ast.ImportSpec, filename, moduleName string) ImportForm {
+	importPath, _ := strconv.Unquote(imp.Path.Value)
+	form := ImportForm{
+		Path: strings.TrimPrefix(importPath, moduleName+"/"),
+		Test: strings.HasSuffix(filename, "_test.go"),
+	}
+	if imp.Name != nil {
+		form.Name = imp.Name.Name
+	}
+	return form
+}
+
+// ImpliedName returns the name an unnamed import of importPath is expected to have:
+// the last element of the path, skipping a major version element such as "/v2"
+// and trimming a gopkg.in version suffix such as ".v3"
+func ImpliedName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersion.MatchString(name) && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath))
+	}
+	return gopkgVersion.ReplaceAllString(name, "")
+}
+
+var (
+	majorVersion = regexp.MustCompile(`^v([2-9]|[1-9][0-9]+)$`)
+	gopkgVersion = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+// ImportedNames maps the import paths of pkg to the names their packages declare
+func ImportedNames(pkg *types.Package) map[string]string {
+	if pkg == nil {
+		return nil
+	}
+	names := make(map[string]string)
+	for _, imp := range pkg.Imports() {
+		names[imp.Path()] = imp.Name()
+	}
+	return names
+}
+
+// CheckImportForms evaluates the import form rules of every spec of cfg selecting currentPkg,
+// returning a violation per spec broken
+func CheckImportForms(cfg *config.Config, currentPkg string, form ImportForm) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckImportForm(spec, currentPkg, form); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckImportForm evaluates whether currentPkg may import a package in the given form under the import rules of spec.
+// An import without a name counts as named after its package when type-checked,
+// or after its path otherwise, see ImpliedName.
+// Returns a *Violation if forbidden, nil otherwise.
+func CheckImportForm(spec config.Spec, currentPkg string, form ImportForm) *Violation {
+	alias := spec.Imports.Aliases[form.Path]
+	v := &Violation{
+		Rule:     spec.Name,
+		Package:  currentPkg,
+		Import:   form.Path,
+		Severity: spec.Severity,
+	}
+	switch form.Name {
+	case "_":
+		if !matchesAnyPattern(spec.Imports.ForbidBlank, form.Path) {
+			return nil
+		}
+		v.Reason = "blank import is forbidden"
+	case ".":
+		if !spec.Imports.ForbidDot || form.Test {
+			return nil
+		}
+		v.Reason = "dot import is forbidden outside test files"
+		v.Rename = alias
+	default:
+		name := form.Name
+		if name == "" {
+			name = form.Package
+		}
+		if name == "" {
+			name = ImpliedName(form.Path)
+		}
+		if alias == "" || name == alias {
+			return nil
+		}
+		v.Reason = fmt.Sprintf("must be imported as %s", alias)
+		v.Rename = alias
+	}
+	return v
+}
+
+// matchesAnyPattern reports whether pkg matches one of the forbid-style patterns
+func matchesAnyPattern(patterns []string, pkg string) bool {
+	for _, pattern := range patterns {
+		if _, ok := MatchPattern(pattern, pkg); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestImpliedName(t *testing.T) {
+	t.Parallel()
+	for importPath, want := range map[string]string{
+		"strings":            "strings",
+		"k8s.io/api/core/v1": "v1",
+		"example.com/x/v2":   "x",
+		"example.com/x/v10":  "x",
+		"gopkg.in/yaml.v3":   "yaml",
+		"v2":                 "v2",
+	} {
+		testutil.Equals(t, ImpliedName(importPath), want)
+	}
+}
+
+func TestCheckImportForm(t *testing.T) {
+	t.Parallel()
+	spec := config.Spec{
+		Name:     "imports",
+		Severity: config.SeverityError,
+		Packages: config.Packages{Include: []string{"**"}},
+		Imports: config.Imports{
+			Aliases: map[string]string{
+				"k8s.io/api/core/v1":       "corev1",
+				"strings":                  "strings",
+				"example.com/x/v2":         "x",
+				"gopkg.in/yaml.v3":         "yaml",
+				"github.com/go-chi/chi/v5": "chi",
+				"example.com/go-sql":       "sql",
+			},
+			ForbidDot:   true,
+			ForbidBlank: []string{"github.com/lib/**"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		form   ImportForm
+		reason string
+		rename string
+	}{
+		{name: "alias", form: ImportForm{Path: "k8s.io/api/core/v1", Name: "corev1"}},
+		{name: "missing alias", form: ImportForm{Path: "k8s.io/api/core/v1"}, reason: "must be imported as corev1", rename: "corev1"},
+		{name: "wrong alias", form: ImportForm{Path: "k8s.io/api/core/v1", Name: "v1"}, reason: "must be imported as corev1", rename: "corev1"},
+		{name: "path name", form: ImportForm{Path: "strings"}},
+		{name: "major version", form: ImportForm{Path: "example.com/x/v2"}},
+		{name: "gopkg.in version", form: ImportForm{Path: "gopkg.in/yaml.v3"}},
+		{name: "major version alias", form: ImportForm{Path: "github.com/go-chi/chi/v5", Name: "v5"}, reason: "must be imported as chi", rename: "chi"},
+		{name: "declared name", form: ImportForm{Path: "example.com/go-sql", Package: "sql"}},
+		{name: "path name without declared name", form: ImportForm{Path: "example.com/go-sql"}, reason: "must be imported as sql", rename: "sql"},
+		{name: "dot", form: ImportForm{Path: "strings", Name: "."}, reason: "dot import is forbidden outside test files", rename: "strings"},
+		{name: "dot in test", form: ImportForm{Path: "strings", Name: ".", Test: true}},
+		{name: "blank", form: ImportForm{Path: "github.com/lib/pq", Name: "_"}, reason: "blank import is forbidden"},
+		{name: "other blank", form: ImportForm{Path: "embed", Name: "_"}},
+		{name: "other import", form: ImportForm{Path: "fmt", Name: "f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CheckImportForm(spec, "cmd/server", tt.form)
+			if tt.reason == "" {
+				testutil.Equals(t, got, (*Violation)(nil))
+				return
+			}
+			testutil.Equals(t, got.Reason, tt.reason)
+			testutil.Equals(t, got.Rename, tt.rename)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Labelled reports whether any spec of cfg forbids labels,
+// which requires the import graph of every dependency
+func Labelled(cfg *config.Config) bool {
+	return slices.ContainsFunc(cfg.Specs, func(spec config.Spec) bool { return len(spec.Labels.Forbid) > 0 })
+}
+
+// CheckLabels evaluates the label rules of every spec of cfg selecting currentPkg
+// for the labels brought in through importedPkg, returning a violation per spec broken
+func CheckLabels(cfg *config.Config, graph *Graph, currentPkg, importedPkg string) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckLabel(cfg, spec, graph, currentPkg, importedPkg); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckLabel evaluates whether currentPkg carries a label forbidden by spec through importedPkg and the imports following it in graph.
+// A package carries a label if it matches its packages, or imports a package matching its imports.
+// Returns a *Violation with the shortest chain bringing in each forbidden label, nil otherwise.
+func CheckLabel(cfg *config.Config, spec config.Spec, graph *Graph, currentPkg, importedPkg string) *Violation {
+	var reasons []string
+	var chain []string
+	for _, name := range spec.Labels.Forbid {
+		label, _ := cfg.Label(name)
+		c := labelChain(label, graph, currentPkg, importedPkg)
+		if c == nil {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("carries label %q through %s", name, strings.Join(c, " -> ")))
+		if chain == nil {
+			chain = c
+		}
+	}
+	if reasons == nil {
+		return nil
+	}
+
+	return &Violation{
+		Rule:     spec.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: spec.Severity,
+		Reason:   strings.Join(reasons, "; "),
+		Chain:    chain,
+	}
+}
+
+// labelChain returns the shortest import chain from currentPkg through importedPkg bringing in label,
+// ending with the package matching its packages or imported by a module package matching its imports, or nil if there is none
+func labelChain(label config.Label, graph *Graph, currentPkg, importedPkg string) []string {
+	always := func(from, to string) bool { return true }
+	// Only the imports of the module packages bring in the label,
+	// not those of the standard library or other dependencies
+	fromModule := func(from, to string) bool {
+		return !matchesAnyPattern(label.Imports, to) || graph.inModule(from)
+	}
+	var chain []string
+	for _, pkg := range append([]string{importedPkg}, graph.reachable(importedPkg)...) {
+		var c []string
+		switch {
+		case matchesAnyPattern(label.Packages, pkg):
+			c = graph.shortestChain(currentPkg, importedPkg, pkg, always)
+		case matchesAnyPattern(label.Imports, pkg):
+			c = graph.shortestChain(currentPkg, importedPkg, pkg, fromModule)
+		}
+		if c != nil && (chain == nil || len(c) < len(chain)) {
+			chain = c
+		}
+	}
+	return chain
+}
@@ -0,0 +1,49 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestCheckLabel(t *testing.T) {
+	t.Parallel()
+	graph := NewGraph(map[string][]string{
+		"api":            {"service", "fmt"},
+		"service":        {"preview/search", "native"},
+		"native":         {"C", "unsafe"},
+		"preview/search": {"strings"},
+		"fmt":            {"errors"},
+		"errors":         {"unsafe"},
+	}, []string{"api", "service", "native", "preview/search"})
+	cfg := &config.Config{Labels: []config.Label{
+		{Name: "experimental", Packages: []string{"preview/**"}},
+		{Name: "cgo", Imports: []string{"C"}},
+		{Name: "unsafe", Imports: []string{"unsafe"}},
+	}}
+	spec := config.Spec{
+		Name:     "stable api",
+		Severity: config.SeverityError,
+		Labels:   config.LabelRules{Forbid: []string{"experimental", "cgo"}},
+	}
+
+	got := CheckLabel(cfg, spec, graph, "api", "service")
+	testutil.Equals(t, got, &Violation{
+		Rule:     "stable api",
+		Package:  "api",
+		Import:   "service",
+		Severity: config.SeverityError,
+		Reason:   `carries label "experimental" through api -> service -> preview/search; carries label "cgo" through api -> service -> native -> C`,
+		Chain:    []string{"api", "service", "preview/search"},
+	})
+	testutil.Equals(t, CheckLabel(cfg, spec, graph, "api", "fmt"), (*Violation)(nil))
+
+	// A package importing a labelled package directly carries the label
+	testutil.Equals(t, CheckLabel(cfg, spec, graph, "service", "native").Chain, []string{"service", "native", "C"})
+
+	// Only the imports of the module packages bring in a label, not those of the standard library
+	unsafe := config.Spec{Name: "safe", Labels: config.LabelRules{Forbid: []string{"unsafe"}}}
+	testutil.Equals(t, CheckLabel(cfg, unsafe, graph, "api", "fmt"), (*Violation)(nil))
+	testutil.Equals(t, CheckLabel(cfg, unsafe, graph, "api", "service").Chain, []string{"api", "service", "native", "unsafe"})
+}
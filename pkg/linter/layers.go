@@ -0,0 +1,59 @@
+package linter
+
+import (
+	"fmt"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// LayerOf returns the index of the first layer containing pkg, or -1 if none does
+func LayerOf(layers *config.Layers, pkg string) int {
+	for i, layer := range layers.Order {
+		if matchesAny(layer.Packages, pkg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CheckLayers evaluates whether currentPkg may import importedPkg under the layer order.
+// Returns a *Violation naming both layers if not, nil otherwise.
+func CheckLayers(layers *config.Layers, currentPkg, importedPkg string) *Violation {
+	if layers == nil {
+		return nil
+	}
+	from, to := LayerOf(layers, currentPkg), LayerOf(layers, importedPkg)
+	if from < 0 || to < 0 || from == to {
+		return nil
+	}
+
+	var reason string
+	switch {
+	case to < from:
+		reason = fmt.Sprintf("layer %q may not import higher layer %q", layers.Order[from].Name, layers.Order[to].Name)
+	case layers.Strict && to > from+1:
+		reason = fmt.Sprintf("layer %q may only import the layer directly below, %q, not %q",
+			layers.Order[from].Name, layers.Order[from+1].Name, layers.Order[to].Name)
+	default:
+		return nil
+	}
+	return &Violation{
+		Rule:     layers.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: layers.Severity,
+		Reason:   reason,
+	}
+}
+
+// matchesAny reports whether pkg matches one of the glob patterns
+func matchesAny(patterns []string, pkg string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, pkg); ok {
+			return true
+		}
+	}
+	return false
+}
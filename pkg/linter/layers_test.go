@@ -0,0 +1,51 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+func TestCheckLayers(t *testing.T) {
+	t.Parallel()
+	layers := &config.Layers{
+		Name:     "layers",
+		Severity: config.SeverityError,
+		Order: []config.Layer{
+			{Name: "api", Packages: []string{"app/api/**"}},
+			{Name: "service", Packages: []string{"app/service/**"}},
+			{Name: "domain", Packages: []string{"app/domain/**"}},
+		},
+	}
+	strict := *layers
+	strict.Strict = true
+
+	t.Run("relaxed", func(t *testing.T) {
+		t.Parallel()
+		checkImports(t, "layers", func(from, to string) *Violation { return CheckLayers(layers, from, to) }, []importCase{
+			{name: "next layer down", from: "app/api", to: "app/service/users"},
+			{name: "skip layer", from: "app/api", to: "app/domain"},
+			{name: "same layer", from: "app/service/users", to: "app/service/orders"},
+			{name: "outside layers", from: "app/domain", to: "app/util"},
+			{name: "prefix of a layer", from: "app/domain", to: "app/apis"},
+			{name: "upward", from: "app/domain", to: "app/api",
+				reason: `layer "domain" may not import higher layer "api"`},
+			{name: "upward from nested package", from: "app/domain/orders/internal", to: "app/service/orders",
+				reason: `layer "domain" may not import higher layer "service"`},
+		})
+	})
+	t.Run("strict", func(t *testing.T) {
+		t.Parallel()
+		checkImports(t, "layers", func(from, to string) *Violation { return CheckLayers(&strict, from, to) }, []importCase{
+			{name: "next layer down", from: "app/api", to: "app/service/users"},
+			{name: "skip layer", from: "app/api", to: "app/domain",
+				reason: `layer "api" may only import the layer directly below, "service", not "domain"`},
+		})
+	})
+	t.Run("no layers", func(t *testing.T) {
+		t.Parallel()
+		checkImports(t, "layers", func(from, to string) *Violation { return CheckLayers(nil, from, to) }, []importCase{
+			{name: "upward", from: "app/domain", to: "app/api"},
+		})
+	})
+}
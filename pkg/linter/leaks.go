@@ -0,0 +1,201 @@
+package linter
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Leak is an exported declaration mentioning a type of another package
+type Leak struct {
+	Pos token.Pos
+	// Declaration describes the exported declaration, such as "field Handler.Repo"
+	Declaration string
+	// Package is the path of the package declaring the type
+	Package string
+	// Name is the name of the type
+	Name string
+}
+
+// leakKey identifies a leak across the package variants declaring it
+type leakKey struct {
+	pos       token.Position
+	pkg, name string
+}
+
+// Leaks lists the types of other packages mentioned by the exported declarations of pkg:
+// function signatures, variable and constant types, struct fields, methods, interface methods and type aliases
+func Leaks(pkg *types.Package) []Leak {
+	if pkg == nil {
+		return nil
+	}
+	var leaks []Leak
+	add := func(pos token.Pos, decl string, t types.Type) {
+		mentions(t, make(map[types.Type]bool), func(obj *types.TypeName) {
+			if obj.Pkg() != nil && obj.Pkg() != pkg {
+				leaks = append(leaks, Leak{Pos: pos, Declaration: decl, Package: obj.Pkg().Path(), Name: obj.Name()})
+			}
+		})
+	}
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		switch obj := obj.(type) {
+		case *types.Func:
+			add(obj.Pos(), "func "+name, obj.Type())
+		case *types.Var:
+			add(obj.Pos(), "var "+name, obj.Type())
+		case *types.Const:
+			add(obj.Pos(), "const "+name, obj.Type())
+		case *types.TypeName:
+			if obj.IsAlias() {
+				add(obj.Pos(), "type "+name, types.Unalias(obj.Type()))
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			switch u := named.Underlying().(type) {
+			case *types.Struct:
+				for i := range u.NumFields() {
+					if field := u.Field(i); field.Exported() {
+						add(field.Pos(), fmt.Sprintf("field %s.%s", name, field.Name()), field.Type())
+					}
+				}
+			case *types.Interface:
+				for i := range u.NumExplicitMethods() {
+					if m := u.ExplicitMethod(i); m.Exported() {
+						add(m.Pos(), fmt.Sprintf("method %s.%s", name, m.Name()), m.Type())
+					}
+				}
+				for i := range u.NumEmbeddeds() {
+					add(obj.Pos(), "type "+name, u.EmbeddedType(i))
+				}
+			default:
+				add(obj.Pos(), "type "+name, u)
+			}
+			for i := range named.NumMethods() {
+				if m := named.Method(i); m.Exported() {
+					sig := m.Type().(*types.Signature)
+					add(m.Pos(), fmt.Sprintf("method %s.%s", name, m.Name()), types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic()))
+				}
+			}
+		}
+	}
+	return leaks
+}
+
+// mentions calls visit for every named type mentioned by t, without looking inside named types
+func mentions(t types.Type, seen map[types.Type]bool, visit func(*types.TypeName)) {
+	if t == nil || seen[t] {
+		return
+	}
+	seen[t] = true
+	switch t := t.(type) {
+	case *types.Named:
+		visit(t.Obj())
+		for i := range t.TypeArgs().Len() {
+			mentions(t.TypeArgs().At(i), seen, visit)
+		}
+	case *types.Alias:
+		visit(t.Obj())
+		mentions(types.Unalias(t), seen, visit)
+	case *types.Pointer:
+		mentions(t.Elem(), seen, visit)
+	case *types.Slice:
+		mentions(t.Elem(), seen, visit)
+	case *types.Array:
+		mentions(t.Elem(), seen, visit)
+	case *types.Chan:
+		mentions(t.Elem(), seen, visit)
+	case *types.Map:
+		mentions(t.Key(), seen, visit)
+		mentions(t.Elem(), seen, visit)
+	case *types.Tuple:
+		for i := range t.Len() {
+			mentions(t.At(i).Type(), seen, visit)
+		}
+	case *types.Signature:
+		mentions(t.Params(), seen, visit)
+		mentions(t.Results(), seen, visit)
+	case *types.Struct:
+		for i := range t.NumFields() {
+			if t.Field(i).Exported() {
+				mentions(t.Field(i).Type(), seen, visit)
+			}
+		}
+	case *types.Interface:
+		for i := range t.NumExplicitMethods() {
+			if t.ExplicitMethod(i).Exported() {
+				mentions(t.ExplicitMethod(i).Type(), seen, visit)
+			}
+		}
+		for i := range t.NumEmbeddeds() {
+			mentions(t.EmbeddedType(i), seen, visit)
+		}
+	}
+}
+
+// CheckLeaks evaluates the export rules of every spec of cfg selecting currentPkg for a leaked type,
+// returning a violation per spec broken.
+// moduleName is trimmed from the package of the type, as for imports.
+func CheckLeaks(cfg *config.Config, moduleName, currentPkg string, leak Leak) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckLeak(spec, moduleName, currentPkg, leak); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckLeak evaluates whether an exported declaration of currentPkg may mention a type under the export rules of spec.
+// Forbid and exempt patterns match the package of the type, and except patterns match currentPkg.
+// Returns a *Violation naming the declaration and the type if forbidden, nil otherwise.
+func CheckLeak(spec config.Spec, moduleName, currentPkg string, leak Leak) *Violation {
+	pkg := strings.TrimPrefix(leak.Package, moduleName+"/")
+	var capturedVars map[string]string
+	forbidden := false
+	for _, pat := range spec.Exports.Forbid {
+		if capturedVars, forbidden = MatchPattern(pat, pkg); forbidden {
+			break
+		}
+	}
+	if !forbidden {
+		return nil
+	}
+
+	now := time.Now()
+	for _, exc := range spec.Exports.Except {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, currentPkg, capturedVars) {
+			return nil
+		}
+	}
+	for _, exc := range spec.Exports.Exempt {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, pkg, capturedVars) {
+			return nil
+		}
+	}
+
+	return &Violation{
+		Rule:        spec.Name,
+		Package:     currentPkg,
+		Import:      pkg,
+		Symbol:      pkg + "." + leak.Name,
+		Declaration: leak.Declaration,
+		Severity:    spec.Severity,
+		Reason:      "leaked by exported " + leak.Declaration,
+	}
+}
@@ -0,0 +1,78 @@
+package linter
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestLeaks(t *testing.T) {
+	t.Parallel()
+	src := `package api
+
+import "time"
+
+type Event struct {
+	At    time.Time
+	delay time.Duration
+}
+
+func (Event) Since(t time.Time) time.Duration { return 0 }
+
+type Clock interface{ Now() time.Time }
+
+type Stamp = time.Time
+
+func New(d []time.Duration) *Event { return nil }
+
+func unexported() time.Time { return time.Time{} }
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "api.go", src, 0)
+	testutil.Equals(t, err, nil)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("example.com/app/api", fset, []*ast.File{file}, nil)
+	testutil.Equals(t, err, nil)
+
+	var got []string
+	for _, leak := range Leaks(pkg) {
+		got = append(got, leak.Declaration+" "+leak.Package+"."+leak.Name)
+	}
+	testutil.Equals(t, got, []string{
+		"method Clock.Now time.Time",
+		"field Event.At time.Time",
+		"method Event.Since time.Time",
+		"method Event.Since time.Duration",
+		"func New time.Duration",
+		"type Stamp time.Time",
+	})
+}
+
+func TestCheckLeak(t *testing.T) {
+	t.Parallel()
+	spec := config.Spec{
+		Name:     "exports",
+		Severity: config.SeverityError,
+		Packages: config.Packages{Include: []string{"**"}},
+		Exports: config.Exports{
+			Forbid: []string{"infrastructure/**"},
+			Except: []config.Exception{{Pattern: "wiring/**"}},
+			Exempt: []config.Exception{{Pattern: "infrastructure/errors"}},
+		},
+	}
+	leak := Leak{Declaration: "field Handler.Repo", Package: "example.com/app/infrastructure/db", Name: "Repo"}
+
+	got := CheckLeak(spec, "example.com/app", "api", leak)
+	testutil.Equals(t, got.Symbol, "infrastructure/db.Repo")
+	testutil.Equals(t, got.Message(), `[exports] package "api" uses "infrastructure/db.Repo": leaked by exported field Handler.Repo`)
+
+	testutil.Equals(t, CheckLeak(spec, "example.com/app", "wiring", leak), (*Violation)(nil))
+	testutil.Equals(t, CheckLeak(spec, "example.com/app", "api", Leak{Package: "example.com/app/infrastructure/errors", Name: "NotFound"}), (*Violation)(nil))
+	testutil.Equals(t, CheckLeak(spec, "example.com/app", "api", Leak{Package: "time", Name: "Time"}), (*Violation)(nil))
+}
@@ -3,13 +3,18 @@ package linter
 import (
 	"bufio"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
 
 	"github.com/TheFellow/arch-lint/pkg/config"
-	"github.com/bmatcuk/doublestar/v4"
 )
 
 var Processed strings.Builder
@@ -19,7 +24,7 @@ func report(str string, args ...any) {
 }
 
 // Run enforces forbidden import rules by analyzing files specified by glob patterns
-func Run(cfg *config.Config) ([]Violation, error) {
+func Run(cfg *config.Config) (*Result, error) {
 
 	moduleName, err := getModuleName()
 	if err != nil {
@@ -31,48 +36,279 @@ func Run(cfg *config.Config) ([]Violation, error) {
 		return nil, err
 	}
 
-	var violations []Violation
-	for _, spec := range cfg.Specs {
-		report("spec: %s\n", spec.Name)
+	fset := token.NewFileSet()
+	files, err := parseFiles(fset, pkgs)
+	if err != nil {
+		return nil, err
+	}
 
+	result := &Result{
+		Checked: make(map[string][]string),
+		Imports: make(map[string][]string),
+	}
+	scanned := make(map[string]bool)
+	for _, pkg := range pkgs {
+		currentPkg := strings.TrimPrefix(pkg.PkgPath, moduleName+"/")
+		if !scanned[currentPkg] {
+			scanned[currentPkg] = true
+			result.Packages = append(result.Packages, currentPkg)
+		}
+		for importPath := range pkg.Imports {
+			if !strings.HasPrefix(importPath, moduleName+"/") {
+				continue
+			}
+			importedPkg := strings.TrimPrefix(importPath, moduleName+"/")
+			if !slices.Contains(result.Imports[currentPkg], importedPkg) {
+				result.Imports[currentPkg] = append(result.Imports[currentPkg], importedPkg)
+			}
+		}
+	}
+	slices.Sort(result.Packages)
+	for _, imports := range result.Imports {
+		slices.Sort(imports)
+	}
+
+	var graph *Graph
+	if Transitive(cfg) || Labelled(cfg) {
+		imports := importGraph(pkgs, moduleName)
+		addSourceImports(imports, pkgs, files, moduleName)
+		graph = NewGraph(imports, result.Packages)
+	}
+
+	cycles := make(map[packageEdge][]Violation)
+	for _, v := range FindCycles(cfg, result.Imports) {
+		e := packageEdge{v.Package, v.Import}
+		cycles[e] = append(cycles[e], v)
+	}
+
+	// Record the deprecation notices of the module packages
+	notices := make(map[string]string)
+	if cfg.DeprecatedDocs != nil {
 		for _, pkg := range pkgs {
-			currentPkg := strings.TrimPrefix(pkg.PkgPath, moduleName+"/")
-
-			// Get packages described by the spec include/exclude patterns
-			included := false
-			for _, includePattern := range spec.Packages.Include {
-				if ok, _ := doublestar.Match(includePattern, currentPkg); ok {
-					included = true
-					break
+			for _, file := range files[pkg] {
+				if notice := DeprecationNotice(file.ast.Doc); notice != "" {
+					notices[strings.TrimPrefix(pkg.PkgPath, moduleName+"/")] = notice
 				}
 			}
-			if !included {
+		}
+	}
+
+	seen := make(map[violationKey]bool)
+	seenUses := make(map[token.Position]bool)
+	seenConstructs := make(map[token.Position]bool)
+	seenLeaks := make(map[leakKey]bool)
+
+	// Adapters may implement the ports of any loaded package, not only those they depend on
+	var loaded []*types.Package
+	for _, pkg := range pkgs {
+		if pkg.Types != nil {
+			loaded = append(loaded, pkg.Types)
+		}
+	}
+
+	for _, pkg := range pkgs {
+		currentPkg := strings.TrimPrefix(pkg.PkgPath, moduleName+"/")
+		report("pkg: %q\n", currentPkg)
+
+		// Record the rules applying to the current package
+		for _, rule := range Applies(cfg, currentPkg) {
+			if !slices.Contains(result.Checked[rule], currentPkg) {
+				report("  rule: %s\n", rule)
+				result.Checked[rule] = append(result.Checked[rule], currentPkg)
+			}
+		}
+
+		// Validate imports of the current package
+		names := ImportedNames(pkg.Types)
+		for _, file := range files[pkg] {
+			for _, imp := range file.ast.Imports {
+				importPath := strings.Trim(imp.Path.Value, `"`)
+				importedPkg := strings.TrimPrefix(importPath, moduleName+"/")
+				report("    import: %q\n", importedPkg)
+				violations := append(Check(cfg, currentPkg, importedPkg), cycles[packageEdge{currentPkg, importedPkg}]...)
+				violations = append(violations, CheckTransitives(cfg, graph, currentPkg, importedPkg)...)
+				violations = append(violations, CheckLabels(cfg, graph, currentPkg, importedPkg)...)
+				form := FormOf(imp, fset.Position(imp.Pos()).Filename, moduleName)
+				form.Package = names[importPath]
+				violations = append(violations, CheckImportForms(cfg, currentPkg, form)...)
+				if strings.HasPrefix(importPath, moduleName+"/") {
+					if v := CheckDeprecatedDoc(cfg.DeprecatedDocs, currentPkg, importedPkg, notices[importedPkg]); v != nil {
+						violations = append(violations, *v)
+					}
+				}
+				for _, v := range violations {
+					if file.suppressions.Suppress(imp, v.Rule) || seen[v.key()] {
+						continue
+					}
+					seen[v.key()] = true
+					v.Position = relativePosition(fset.Position(imp.Pos()))
+					result.Violations = append(result.Violations, v)
+				}
+			}
+		}
+
+		// Validate the symbols used by the current package, reporting each use
+		for _, use := range Uses(pkg.Syntax, pkg.TypesInfo, pkg.Types) {
+			pos := pkg.Fset.Position(use.Ident.Pos())
+			if seenUses[pos] {
+				continue
+			}
+			seenUses[pos] = true
+			for _, v := range append(CheckSymbols(cfg, moduleName, currentPkg, use), CheckDomainUses(cfg, moduleName, currentPkg, use)...) {
+				v.Position = relativePosition(pos)
+				result.Violations = append(result.Violations, v)
+			}
+		}
+
+		// Validate the language constructs of the current package
+		for _, construct := range Constructs(pkg.Fset, pkg.Syntax) {
+			pos := pkg.Fset.Position(construct.Pos)
+			if seenConstructs[pos] {
 				continue
 			}
-			excluded := false
-			for _, excludePattern := range spec.Packages.Exclude {
-				if ok, _ := doublestar.Match(excludePattern, currentPkg); ok {
-					excluded = true
-					break
+			seenConstructs[pos] = true
+			for _, v := range CheckConstructs(cfg, currentPkg, construct) {
+				v.Position = relativePosition(pos)
+				result.Violations = append(result.Violations, v)
+			}
+		}
+
+		// Validate the types leaked by the exported declarations of the current package
+		if cfg.NeedsTypes() {
+			for _, leak := range Leaks(pkg.Types) {
+				pos := pkg.Fset.Position(leak.Pos)
+				key := leakKey{pos, leak.Package, leak.Name}
+				if seenLeaks[key] {
+					continue
+				}
+				seenLeaks[key] = true
+				for _, v := range CheckLeaks(cfg, moduleName, currentPkg, leak) {
+					v.Position = relativePosition(pos)
+					result.Violations = append(result.Violations, v)
 				}
 			}
-			if excluded {
+			for _, v := range CheckAdapters(cfg, moduleName, currentPkg, pkg.Types, loaded) {
+				if seen[v.key()] || len(pkg.Syntax) == 0 {
+					continue
+				}
+				seen[v.key()] = true
+				v.Position = relativePosition(pkg.Fset.Position(pkg.Syntax[0].Name.Pos()))
+				result.Violations = append(result.Violations, v)
+			}
+		}
+	}
+
+	for _, checked := range result.Checked {
+		slices.Sort(checked)
+	}
+
+	for _, pkg := range result.Packages {
+		if msg, ok := ComponentIssue(cfg.Components, pkg); ok {
+			result.Issues = append(result.Issues, Issue{
+				Position: relativePosition(token.Position{Filename: cfg.Path}),
+				Severity: cfg.Components.Severity,
+				Message:  msg,
+			})
+		}
+	}
+
+	for _, e := range Expired(cfg) {
+		result.Issues = append(result.Issues, Issue{
+			Position: relativePosition(token.Position{Filename: cfg.Path}),
+			Severity: e.Severity,
+			Message:  e.String(),
+		})
+	}
+
+	reported := make(map[*sourceFile]bool)
+	for _, pkg := range pkgs {
+		for _, file := range files[pkg] {
+			if reported[file] {
 				continue
 			}
+			reported[file] = true
+			for _, issue := range file.suppressions.Issues() {
+				result.Issues = append(result.Issues, Issue{
+					Position: relativePosition(fset.Position(issue.Pos)),
+					Severity: issue.Severity,
+					Message:  issue.Message,
+				})
+			}
+		}
+	}
 
-			// Validate imports of the current package
-			report("  pkg: %q\n", currentPkg)
-			for importPath := range pkg.Imports {
-				importedPkg := strings.TrimPrefix(importPath, moduleName+"/")
-				report("    import: %q\n", importedPkg)
-				if v := CheckImport(spec, currentPkg, importedPkg); v != nil {
-					violations = append(violations, *v)
+	return result, nil
+}
+
+// importGraph lists the imports of every package and its dependencies, with module packages relative to the module
+func importGraph(pkgs []*packages.Package, moduleName string) map[string][]string {
+	graph := make(map[string][]string)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		from := strings.TrimPrefix(pkg.PkgPath, moduleName+"/")
+		for _, imp := range pkg.Imports {
+			to := strings.TrimPrefix(imp.PkgPath, moduleName+"/")
+			if !slices.Contains(graph[from], to) {
+				graph[from] = append(graph[from], to)
+			}
+		}
+	})
+	for _, imports := range graph {
+		slices.Sort(imports)
+	}
+	return graph
+}
+
+// addSourceImports adds the imports of the module package files to graph,
+// including "C" which is not a package
+func addSourceImports(graph map[string][]string, pkgs []*packages.Package, files map[*packages.Package][]*sourceFile, moduleName string) {
+	for _, pkg := range pkgs {
+		from := strings.TrimPrefix(pkg.PkgPath, moduleName+"/")
+		for _, file := range files[pkg] {
+			for _, imp := range file.ast.Imports {
+				to := strings.TrimPrefix(strings.Trim(imp.Path.Value, `"`), moduleName+"/")
+				if !slices.Contains(graph[from], to) {
+					graph[from] = append(graph[from], to)
+				}
+			}
+		}
+		slices.Sort(graph[from])
+	}
+}
+
+type sourceFile struct {
+	ast          *ast.File
+	suppressions *Suppressions
+}
+
+// parseFiles parses the imports and comments of every package file
+func parseFiles(fset *token.FileSet, pkgs []*packages.Package) (map[*packages.Package][]*sourceFile, error) {
+	files := make(map[*packages.Package][]*sourceFile)
+	parsed := make(map[string]*sourceFile)
+	for _, pkg := range pkgs {
+		for _, name := range pkg.GoFiles {
+			file, ok := parsed[name]
+			if !ok {
+				f, err := parser.ParseFile(fset, name, nil, parser.ImportsOnly|parser.ParseComments)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse %s: %w", name, err)
 				}
+				file = &sourceFile{ast: f, suppressions: ParseSuppressions(f)}
+				parsed[name] = file
 			}
+			files[pkg] = append(files[pkg], file)
 		}
 	}
+	return files, nil
+}
 
-	return violations, nil
+// relativePosition makes the position filename relative to the working directory
+func relativePosition(pos token.Position) token.Position {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, pos.Filename); err == nil {
+			pos.Filename = rel
+		}
+	}
+	return pos
 }
 
 // getModuleName extracts the module name from go.mod
@@ -99,6 +335,16 @@ func loadPackages(cfg *config.Config) ([]*packages.Package, error) {
 	cfgs := &packages.Config{
 		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports,
 	}
+	if Transitive(cfg) || Labelled(cfg) {
+		cfgs.Mode |= packages.NeedDeps
+	}
+	if cfg.HasConstructs() {
+		cfgs.Mode |= packages.NeedSyntax
+	}
+	if cfg.NeedsTypes() {
+		// Type-checking from source needs the types of every dependency
+		cfgs.Mode |= packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps
+	}
 	if cfg.IncludeTests {
 		cfgs.Tests = true
 		cfgs.Mode |= packages.NeedForTest
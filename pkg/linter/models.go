@@ -1,6 +1,11 @@
 package linter
 
-import "fmt"
+import (
+	"fmt"
+	"go/token"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
 
 // GoPackage mirrors `go list -json` output
 type GoPackage struct {
@@ -11,10 +16,138 @@ type GoPackage struct {
 // Violation represents a rule violation
 type Violation struct {
 	Package string
-	Import  string
-	Rule    string
+	// Import is the offending import, empty for violations of the package as a whole
+	Import   string
+	Rule     string
+	Severity config.Severity
+	// Symbol is the forbidden object of a symbol rule, qualified by Import,
+	// or the forbidden construct of a construct rule, such as "go Publish"
+	Symbol string
+	// Declaration is the exported declaration leaking Symbol under an export rule, such as "field Handler.Repo"
+	Declaration string
+	// Position of the offending import or symbol use, if known
+	Position token.Position
+	// Reason explains the violation when the rule alone does not
+	Reason string
+	// Rename is the name an import form violation should import the package as,
+	// empty for a dot import that should use the package name
+	Rename string
+	// Replacement is the import path a deprecated import may be rewritten to, if compatible
+	Replacement string
+	// Chain is the import chain from Package to the forbidden package of a transitive rule
+	Chain []string
+	// Expired lists the exceptions that would have allowed the import had they not expired
+	Expired []Expiry
+}
+
+type violationKey struct {
+	rule, pkg, imp, sym, decl string
+}
+
+func (v Violation) key() violationKey {
+	return violationKey{v.Rule, v.Package, v.Import, v.Symbol, v.Declaration}
 }
 
 func (v Violation) String() string {
-	return fmt.Sprintf("arch-lint: [%s] package %q imports %q", v.Rule, v.Package, v.Import)
+	return fmt.Sprintf("arch-lint: %s: %s", v.Severity, v.Message())
+}
+
+// Message describes the violation without the arch-lint and severity prefix
+func (v Violation) Message() string {
+	if v.Import == "" {
+		return fmt.Sprintf("[%s] package %q", v.Rule, v.Package) + v.reason()
+	}
+	if v.Symbol != "" {
+		return fmt.Sprintf("[%s] package %q uses %q", v.Rule, v.Package, v.Symbol) + v.reason()
+	}
+	return fmt.Sprintf("[%s] package %q imports %q", v.Rule, v.Package, v.Import) + v.reason()
+}
+
+// Diagnostic describes the violation from the point of view of the offending file
+func (v Violation) Diagnostic() string {
+	if v.Import == "" {
+		return fmt.Sprintf("[%s] %s", v.Rule, v.Reason)
+	}
+	if v.Symbol != "" {
+		return fmt.Sprintf("[%s] forbidden use of %q", v.Rule, v.Symbol) + v.reason()
+	}
+	return fmt.Sprintf("[%s] forbidden import of %q", v.Rule, v.Import) + v.reason()
+}
+
+func (v Violation) reason() string {
+	if v.Reason == "" {
+		return ""
+	}
+	return ": " + v.Reason
+}
+
+// Rule describes a configured rule that violations are reported against
+type Rule struct {
+	Name        string
+	Description string
+	Severity    config.Severity
+}
+
+// Rules lists the rules configured in cfg
+func Rules(cfg *config.Config) []Rule {
+	var rules []Rule
+	for _, spec := range cfg.Specs {
+		rules = append(rules, Rule{Name: spec.Name, Description: spec.Description, Severity: spec.Severity})
+	}
+	if cfg.Layers != nil {
+		rules = append(rules, Rule{Name: cfg.Layers.Name, Description: "ordered layers", Severity: cfg.Layers.Severity})
+	}
+	if cfg.Components != nil {
+		rules = append(rules, Rule{Name: cfg.Components.Name, Description: "component dependencies", Severity: cfg.Components.Severity})
+	}
+	for _, cycles := range cfg.Cycles {
+		rules = append(rules, Rule{Name: cycles.Name, Description: "no import cycles between groups", Severity: cycles.Severity})
+	}
+	for _, visibility := range cfg.Visibility {
+		description := visibility.Description
+		if description == "" {
+			description = "package visibility"
+		}
+		rules = append(rules, Rule{Name: visibility.Name, Description: description, Severity: visibility.Severity})
+	}
+	for _, facade := range cfg.Facades {
+		rules = append(rules, Rule{Name: facade.Name, Description: "imports through component facades", Severity: facade.Severity})
+	}
+	for _, hex := range cfg.Hexagonal {
+		rules = append(rules, Rule{Name: hex.Name, Description: "ports and adapters", Severity: hex.Severity})
+	}
+	for _, d := range cfg.Deprecate {
+		rules = append(rules, Rule{Name: d.Name, Description: fmt.Sprintf("%s is deprecated", d.From), Severity: d.Severity})
+	}
+	if cfg.DeprecatedDocs != nil {
+		rules = append(rules, Rule{Name: cfg.DeprecatedDocs.Name, Description: "packages documented as deprecated", Severity: cfg.DeprecatedDocs.Severity})
+	}
+	return rules
+}
+
+// Result is the outcome of a lint run
+type Result struct {
+	Violations []Violation
+	Issues     []Issue
+	// Packages lists every package scanned
+	Packages []string
+	// Checked lists the packages each rule applies to, keyed by rule name
+	Checked map[string][]string
+	// Imports lists the packages of the module imported by each scanned package
+	Imports map[string][]string
+}
+
+// Issue is a problem found during a run that is not a rule violation
+type Issue struct {
+	Position token.Position
+	// Severity decides whether the issue fails the run, like the severity of a violation
+	Severity config.Severity
+	Message  string
+}
+
+func (i Issue) String() string {
+	if !i.Position.IsValid() {
+		return fmt.Sprintf("arch-lint: %s: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("arch-lint: %s: %s: %s", i.Severity, i.Position, i.Message)
 }
@@ -1,8 +1,10 @@
 package linter
 
 import (
+	"go/token"
 	"testing"
 
+	"github.com/TheFellow/arch-lint/pkg/config"
 	"github.com/TheFellow/arch-lint/pkg/testutil"
 )
 
@@ -14,6 +16,20 @@ func TestViolation_String(t *testing.T) {
 		Import:  "path/to/bar",
 	}
 	got := v.String()
-	want := `arch-lint: [my-rule] package "path/to" imports "path/to/bar"`
+	want := `arch-lint: error: [my-rule] package "path/to" imports "path/to/bar"`
 	testutil.Equals(t, got, want)
+
+	v.Severity = config.SeverityWarning
+	got = v.String()
+	want = `arch-lint: warning: [my-rule] package "path/to" imports "path/to/bar"`
+	testutil.Equals(t, got, want)
+}
+
+func TestIssue_String(t *testing.T) {
+	t.Parallel()
+	issue := Issue{Severity: config.SeverityWarning, Message: "unused suppression of [my-rule]"}
+	testutil.Equals(t, issue.String(), `arch-lint: warning: unused suppression of [my-rule]`)
+
+	issue.Position = token.Position{Filename: "path/to/file.go", Line: 3, Column: 2}
+	testutil.Equals(t, issue.String(), `arch-lint: warning: path/to/file.go:3:2: unused suppression of [my-rule]`)
 }
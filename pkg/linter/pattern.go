@@ -2,14 +2,34 @@ package linter
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 )
 
+// compiled memoizes the regular expressions of the patterns, keyed by expression,
+// as every import and every hop of a transitive chain is matched against the same patterns
+var compiled sync.Map
+
+// compile compiles expr once, returning the cached *regexp.Regexp afterwards
+func compile(expr string) (*regexp.Regexp, error) {
+	if re, ok := compiled.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	compiled.Store(expr, re)
+	return re, nil
+}
+
 func MatchPattern(pattern, path string) (map[string]string, bool) {
 	regexPattern := EscapePattern(pattern)
 
-	re, err := regexp.Compile(regexPattern)
+	re, err := compile(regexPattern)
 	if err != nil {
 		return nil, false
 	}
@@ -83,19 +103,25 @@ func ReplaceVariables(pattern string, vars map[string]string) string {
 }
 
 func ExceptRegex(pattern, path string, vars map[string]string) bool {
+	ok, _ := exceptMatch(pattern, path, vars)
+	return ok
+}
+
+// exceptMatch is ExceptRegex, also describing why the pattern did or did not match
+func exceptMatch(pattern, path string, vars map[string]string) (bool, string) {
 	// Replace variables in the pattern
 	regexPattern := EscapePattern(ReplaceVariables(pattern, vars))
 
 	// Compile the regex
-	re, err := regexp.Compile(regexPattern)
+	re, err := compile(regexPattern)
 	if err != nil {
-		return false
+		return false, fmt.Sprintf("invalid pattern: %v", err)
 	}
 
 	// Match the path against the regex
 	match := re.FindStringSubmatch(path)
 	if match == nil {
-		return false
+		return false, fmt.Sprintf("%q does not match the pattern", path)
 	}
 
 	// Extract named groups into a map
@@ -107,22 +133,31 @@ func ExceptRegex(pattern, path string, vars map[string]string) bool {
 	}
 
 	// Validate variables (both positive and negated)
-	for key, value := range vars {
+	var reasons []string
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		value := vars[key]
 		negatedPlaceholder := fmt.Sprintf("{!%s}", key)
 		positivePlaceholder := fmt.Sprintf("{%s}", key)
 
 		if strings.Contains(pattern, negatedPlaceholder) {
 			// For negated variables, ensure the captured value does not match the forbidden value
 			if capturedVars[key] == value {
-				return false // Negated variable matches the forbidden value
+				// Negated variable matches the forbidden value
+				return false, fmt.Sprintf("negated variable %s equals captured value %s", negatedPlaceholder, value)
 			}
+			reasons = append(reasons, fmt.Sprintf("negated variable %s is %s, not captured value %s", negatedPlaceholder, capturedVars[key], value))
 		} else if strings.Contains(pattern, positivePlaceholder) {
 			// For positive variables, ensure the captured value matches the expected value
 			if capturedVars[key] != value {
-				return false // Positive variable does not match the expected value
+				// Positive variable does not match the expected value
+				return false, fmt.Sprintf("variable %s is %s, not captured value %s", positivePlaceholder, capturedVars[key], value)
 			}
+			reasons = append(reasons, fmt.Sprintf("variable %s equals captured value %s", positivePlaceholder, value))
 		}
 	}
 
-	return true
+	if len(reasons) == 0 {
+		return true, fmt.Sprintf("%q matches the pattern", path)
+	}
+	return true, fmt.Sprintf("%q matches the pattern, %s", path, strings.Join(reasons, ", "))
 }
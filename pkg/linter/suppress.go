@@ -0,0 +1,165 @@
+package linter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+const ignoreDirective = "//arch-lint:ignore"
+
+// Suppression is an `//arch-lint:ignore <spec name> -- <reason>` comment.
+type Suppression struct {
+	Rule   string
+	Reason string
+	Pos    token.Pos
+	used   bool
+}
+
+func (s Suppression) String() string {
+	return fmt.Sprintf("%s %s -- %s", ignoreDirective, s.Rule, s.Reason)
+}
+
+// Suppressions holds the suppression comments of a single file.
+// A suppression applies to the import on the same line, the import below it,
+// or every import of the import block below it.
+type Suppressions struct {
+	imports map[*ast.ImportSpec][]*Suppression
+	all     []*Suppression
+	invalid []*Suppression
+}
+
+// ParseSuppressions collects the suppression comments attached to the imports of file.
+func ParseSuppressions(file *ast.File) *Suppressions {
+	s := &Suppressions{imports: make(map[*ast.ImportSpec][]*Suppression)}
+	parsed := make(map[*ast.CommentGroup][]*Suppression)
+	parse := func(group *ast.CommentGroup) []*Suppression {
+		if group == nil {
+			return nil
+		}
+		if sups, ok := parsed[group]; ok {
+			return sups
+		}
+		var sups []*Suppression
+		for _, c := range group.List {
+			sup, ok := parseSuppression(c)
+			if !ok {
+				continue
+			}
+			if sup.Rule == "" || sup.Reason == "" {
+				s.invalid = append(s.invalid, sup)
+				continue
+			}
+			s.all = append(s.all, sup)
+			sups = append(sups, sup)
+		}
+		parsed[group] = sups
+		return sups
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		block := parse(gen.Doc)
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			sups := append([]*Suppression{}, block...)
+			for _, group := range []*ast.CommentGroup{imp.Doc, imp.Comment} {
+				if group != gen.Doc {
+					sups = append(sups, parse(group)...)
+				}
+			}
+			s.imports[imp] = sups
+		}
+	}
+	return s
+}
+
+func parseSuppression(c *ast.Comment) (*Suppression, bool) {
+	text, ok := strings.CutPrefix(c.Text, ignoreDirective)
+	if !ok || (text != "" && text[0] != ' ' && text[0] != '\t') {
+		return nil, false
+	}
+	rule, reason, _ := strings.Cut(text, "--")
+	return &Suppression{
+		Rule:   strings.TrimSpace(rule),
+		Reason: strings.TrimSpace(reason),
+		Pos:    c.Pos(),
+	}, true
+}
+
+// Suppress reports whether a violation of rule at imp is suppressed,
+// and marks the matching suppression as used.
+func (s *Suppressions) Suppress(imp *ast.ImportSpec, rule string) bool {
+	for _, sup := range s.imports[imp] {
+		if sup.Rule == rule {
+			sup.used = true
+			return true
+		}
+	}
+	return false
+}
+
+// Unchecked marks the suppressions of rules the caller cannot evaluate as used,
+// so they are not reported as unused
+func (s *Suppressions) Unchecked(rules []string) {
+	for _, sup := range s.all {
+		if slices.Contains(rules, sup.Rule) {
+			sup.used = true
+		}
+	}
+}
+
+// Unused returns the suppressions that did not suppress any violation.
+func (s *Suppressions) Unused() []Suppression {
+	var unused []Suppression
+	for _, sup := range s.all {
+		if !sup.used {
+			unused = append(unused, *sup)
+		}
+	}
+	return unused
+}
+
+// Invalid returns the suppressions missing a spec name or a reason.
+func (s *Suppressions) Invalid() []Suppression {
+	var invalid []Suppression
+	for _, sup := range s.invalid {
+		invalid = append(invalid, *sup)
+	}
+	return invalid
+}
+
+// Issues returns the invalid and unused suppressions of the file.
+func (s *Suppressions) Issues() []SuppressionIssue {
+	var issues []SuppressionIssue
+	for _, sup := range s.Invalid() {
+		issues = append(issues, SuppressionIssue{
+			Pos:      sup.Pos,
+			Severity: config.SeverityError,
+			Message:  fmt.Sprintf("suppression must name a spec and give a reason: %s <spec name> -- <reason>", ignoreDirective),
+		})
+	}
+	for _, sup := range s.Unused() {
+		issues = append(issues, SuppressionIssue{
+			Pos:      sup.Pos,
+			Severity: config.SeverityWarning,
+			Message:  fmt.Sprintf("unused suppression of [%s]", sup.Rule),
+		})
+	}
+	return issues
+}
+
+// SuppressionIssue is a problem with a suppression comment.
+// Invalid suppressions are errors, unused ones warnings.
+type SuppressionIssue struct {
+	Pos      token.Pos
+	Severity config.Severity
+	Message  string
+}
@@ -0,0 +1,71 @@
+package linter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	t.Parallel()
+	src := `package p
+
+//arch-lint:ignore block rule -- applies to the whole block
+import (
+	//arch-lint:ignore spec rule -- applies to the import below
+	"a"
+	"b" //arch-lint:ignore line rule -- applies to this line
+	"c" //arch-lint:ignore missing reason
+	"d" //arch-lint:ignore unused rule -- never matched
+)
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ImportsOnly|parser.ParseComments)
+	testutil.Equals(t, err, nil)
+	imports := make(map[string]*ast.ImportSpec)
+	for _, imp := range file.Imports {
+		imports[imp.Path.Value[1:len(imp.Path.Value)-1]] = imp
+	}
+
+	s := ParseSuppressions(file)
+	testutil.Equals(t, s.Suppress(imports["a"], "spec rule"), true)
+	testutil.Equals(t, s.Suppress(imports["a"], "block rule"), true)
+	testutil.Equals(t, s.Suppress(imports["b"], "spec rule"), false)
+	testutil.Equals(t, s.Suppress(imports["b"], "line rule"), true)
+	testutil.Equals(t, s.Suppress(imports["c"], "missing reason"), false)
+
+	var unused []string
+	for _, sup := range s.Unused() {
+		unused = append(unused, sup.Rule)
+	}
+	testutil.Equals(t, unused, []string{"unused rule"})
+
+	issues := s.Issues()
+	testutil.Equals(t, len(issues), 2)
+	testutil.Equals(t, fset.Position(issues[0].Pos).Line, 8)
+	testutil.Equals(t, issues[1].Message, "unused suppression of [unused rule]")
+}
+
+func TestSuppressions_Unchecked(t *testing.T) {
+	t.Parallel()
+	src := `package p
+
+import (
+	"a" //arch-lint:ignore cycles -- checked by the CLI only
+	"b" //arch-lint:ignore unused rule -- never matched
+)
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ImportsOnly|parser.ParseComments)
+	testutil.Equals(t, err, nil)
+
+	s := ParseSuppressions(file)
+	s.Unchecked([]string{"cycles"})
+	var unused []string
+	for _, sup := range s.Unused() {
+		unused = append(unused, sup.Rule)
+	}
+	testutil.Equals(t, unused, []string{"unused rule"})
+}
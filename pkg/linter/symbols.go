@@ -0,0 +1,144 @@
+package linter
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Use is a reference to a package-level object, or method, of another package
+type Use struct {
+	Ident *ast.Ident
+	// Package is the path of the package declaring the object
+	Package string
+	// Name is the object name, qualified by its type for methods
+	Name string
+}
+
+// Symbol returns the fully qualified name of the object used
+func (u Use) Symbol() string {
+	return u.Package + "." + u.Name
+}
+
+// Uses lists the references in files to objects of packages other than pkg, in source order
+func Uses(files []*ast.File, info *types.Info, pkg *types.Package) []Use {
+	if info == nil || info.Uses == nil {
+		return nil
+	}
+	var uses []Use
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			obj := info.Uses[ident]
+			if obj == nil || obj.Pkg() == nil || obj.Pkg() == pkg {
+				return true
+			}
+			if name, ok := objectName(obj); ok {
+				uses = append(uses, Use{Ident: ident, Package: obj.Pkg().Path(), Name: name})
+			}
+			return true
+		})
+	}
+	return uses
+}
+
+// objectName names a package-level object or method, reporting false for anything else
+func objectName(obj types.Object) (string, bool) {
+	if fn, ok := obj.(*types.Func); ok {
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			t := recv.Type()
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if named, ok := t.(*types.Named); ok {
+				return named.Obj().Name() + "." + fn.Name(), true
+			}
+			return "", false
+		}
+	}
+	if obj.Parent() != obj.Pkg().Scope() {
+		return "", false
+	}
+	return obj.Name(), true
+}
+
+// CheckSymbols evaluates the symbol rules of every spec of cfg selecting currentPkg
+// for a use of another package's object, returning a violation per spec broken.
+// moduleName is trimmed from the package of the object, as for imports.
+func CheckSymbols(cfg *config.Config, moduleName, currentPkg string, use Use) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckSymbol(spec, moduleName, currentPkg, use); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckSymbol evaluates whether currentPkg may use the object under the symbol rules of spec.
+// Forbid and exempt patterns match the fully qualified symbol, or its module relative form,
+// and except patterns match currentPkg. Returns a *Violation if forbidden, nil otherwise.
+func CheckSymbol(spec config.Spec, moduleName, currentPkg string, use Use) *Violation {
+	pkg := strings.TrimPrefix(use.Package, moduleName+"/")
+	var capturedVars map[string]string
+	forbidden := false
+	for _, pat := range spec.Symbols.Forbid {
+		if capturedVars, forbidden = matchSymbol(pat, use.Package, pkg, use.Name); forbidden {
+			break
+		}
+	}
+	if !forbidden {
+		return nil
+	}
+
+	now := time.Now()
+	for _, exc := range spec.Symbols.Except {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, currentPkg, capturedVars) {
+			return nil
+		}
+	}
+	for _, exc := range spec.Symbols.Exempt {
+		if _, ok := matchSymbol(exc.Pattern, use.Package, pkg, use.Name); ok && !exc.Expired(now) {
+			return nil
+		}
+	}
+
+	return &Violation{
+		Rule:     spec.Name,
+		Package:  currentPkg,
+		Import:   pkg,
+		Symbol:   pkg + "." + use.Name,
+		Severity: spec.Severity,
+	}
+}
+
+// matchSymbol matches a "<package pattern>.<name glob>" pattern against an object,
+// trying the fully qualified and module relative package path.
+// The package path may itself contain dots, so every split of the last path segment is tried.
+func matchSymbol(pattern, fullPkg, relPkg, name string) (map[string]string, bool) {
+	dir := strings.LastIndex(pattern, "/") + 1
+	for i := dir; i < len(pattern); i++ {
+		if pattern[i] != '.' {
+			continue
+		}
+		if ok, _ := path.Match(pattern[i+1:], name); !ok {
+			continue
+		}
+		for _, pkg := range []string{fullPkg, relPkg} {
+			if vars, ok := MatchPattern(pattern[:i], pkg); ok {
+				return vars, true
+			}
+		}
+	}
+	return nil, false
+}
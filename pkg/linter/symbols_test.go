@@ -0,0 +1,50 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestCheckSymbol(t *testing.T) {
+	t.Parallel()
+	spec := config.Spec{
+		Name:     "symbols",
+		Severity: config.SeverityError,
+		Packages: config.Packages{Include: []string{"**"}},
+		Symbols: config.Symbols{
+			Forbid: []string{"time.Now", "math/rand.*", "gopkg.in/yaml.v3.Unmarshal", "example.com/app/db.New*"},
+			Except: []config.Exception{{Pattern: "wiring/**"}},
+			Exempt: []config.Exception{{Pattern: "math/rand.New"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		from   string
+		use    Use
+		symbol string
+	}{
+		{name: "function", from: "domain", use: Use{Package: "time", Name: "Now"}, symbol: "time.Now"},
+		{name: "other function", from: "domain", use: Use{Package: "time", Name: "Since"}},
+		{name: "glob", from: "domain", use: Use{Package: "math/rand", Name: "Rand.Intn"}, symbol: "math/rand.Rand.Intn"},
+		{name: "exempt", from: "domain", use: Use{Package: "math/rand", Name: "New"}},
+		{name: "dotted package", from: "domain", use: Use{Package: "gopkg.in/yaml.v3", Name: "Unmarshal"}, symbol: "gopkg.in/yaml.v3.Unmarshal"},
+		{name: "module package", from: "domain", use: Use{Package: "example.com/app/db", Name: "NewRepo"}, symbol: "db.NewRepo"},
+		{name: "except", from: "wiring", use: Use{Package: "example.com/app/db", Name: "NewRepo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CheckSymbol(spec, "example.com/app", tt.from, tt.use)
+			if tt.symbol == "" {
+				testutil.Equals(t, got, (*Violation)(nil))
+				return
+			}
+			testutil.Equals(t, got.Symbol, tt.symbol)
+			testutil.Equals(t, got.Diagnostic(), `[symbols] forbidden use of "`+tt.symbol+`"`)
+			testutil.Equals(t, got.Message(), `[symbols] package "`+tt.from+`" uses "`+tt.symbol+`"`)
+		})
+	}
+}
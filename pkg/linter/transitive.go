@@ -0,0 +1,193 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// Transitive reports whether any spec of cfg forbids transitive dependencies,
+// which requires the import graph of every dependency
+func Transitive(cfg *config.Config) bool {
+	return slices.ContainsFunc(cfg.Specs, func(spec config.Spec) bool { return spec.Rules.Transitive })
+}
+
+// CheckTransitives evaluates every transitive spec of cfg selecting currentPkg
+// for the dependencies reached through importedPkg, returning a violation per spec broken
+func CheckTransitives(cfg *config.Config, graph *Graph, currentPkg, importedPkg string) []Violation {
+	var violations []Violation
+	for _, spec := range cfg.Specs {
+		if !Selects(spec, currentPkg) {
+			continue
+		}
+		if v := CheckTransitive(spec, graph, currentPkg, importedPkg); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
+
+// CheckTransitive evaluates whether currentPkg depends on a package forbidden by spec
+// through importedPkg and the imports following it in graph.
+// Except and exempt patterns apply to each hop of the chain, an except to the importer and an exempt to the imported package.
+// Returns a *Violation with the shortest offending chain, nil otherwise.
+// importedPkg itself being forbidden is left to CheckImport.
+func CheckTransitive(spec config.Spec, graph *Graph, currentPkg, importedPkg string) *Violation {
+	if !spec.Rules.Transitive {
+		return nil
+	}
+
+	now := time.Now()
+	var target string
+	var chain []string
+	for _, f := range graph.forbidden(spec, importedPkg) {
+		allowed := func(from, to string) bool {
+			return !excepted(spec, from, to, f.capturedVars, now)
+		}
+		c := graph.shortestChain(currentPkg, importedPkg, f.pkg, allowed)
+		if c != nil && (chain == nil || len(c) < len(chain)) {
+			target, chain = f.pkg, c
+		}
+	}
+	if chain == nil {
+		return nil
+	}
+
+	return &Violation{
+		Rule:     spec.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: spec.Severity,
+		Reason:   fmt.Sprintf("depends on %q through %s", target, strings.Join(chain, " -> ")),
+		Chain:    chain,
+	}
+}
+
+// excepted reports whether the hop from one package to another is allowed by an unexpired
+// except pattern matching the importer or exempt pattern matching the imported package
+func excepted(spec config.Spec, from, to string, capturedVars map[string]string, now time.Time) bool {
+	for _, exc := range spec.Rules.Except {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, from, capturedVars) {
+			return true
+		}
+	}
+	for _, exc := range spec.Rules.Exempt {
+		if !exc.Expired(now) && ExceptRegex(exc.Pattern, to, capturedVars) {
+			return true
+		}
+	}
+	return false
+}
+
+// Graph is an import graph, with module packages relative to the module.
+// It memoizes the packages reachable from each package, and those forbidden by each transitive spec,
+// as every import of a package is checked against them.
+type Graph struct {
+	imports map[string][]string
+	module  map[string]bool
+	reach   map[string][]string
+	forbids map[forbiddenKey][]forbiddenPkg
+}
+
+// forbiddenKey identifies the packages reachable from pkg forbidden by the spec named spec
+type forbiddenKey struct {
+	spec, pkg string
+}
+
+// forbiddenPkg is a package forbidden by a spec, with the variables its forbid pattern captured
+type forbiddenPkg struct {
+	pkg          string
+	capturedVars map[string]string
+}
+
+// NewGraph creates a Graph from the imports of each package, module listing the packages of the module
+func NewGraph(imports map[string][]string, module []string) *Graph {
+	g := &Graph{
+		imports: imports,
+		module:  make(map[string]bool),
+		reach:   make(map[string][]string),
+		forbids: make(map[forbiddenKey][]forbiddenPkg),
+	}
+	for _, pkg := range module {
+		g.module[pkg] = true
+	}
+	return g
+}
+
+// inModule reports whether pkg is a package of the module
+func (g *Graph) inModule(pkg string) bool {
+	return g.module[pkg]
+}
+
+// reachable returns the packages reachable from pkg, nearest first, excluding pkg
+func (g *Graph) reachable(pkg string) []string {
+	if found, ok := g.reach[pkg]; ok {
+		return found
+	}
+	seen := map[string]bool{pkg: true}
+	queue := []string{pkg}
+	var found []string
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range g.imports[node] {
+			if !seen[next] {
+				seen[next] = true
+				found = append(found, next)
+				queue = append(queue, next)
+			}
+		}
+	}
+	g.reach[pkg] = found
+	return found
+}
+
+// forbidden returns the packages reachable from pkg that spec forbids, nearest first
+func (g *Graph) forbidden(spec config.Spec, pkg string) []forbiddenPkg {
+	key := forbiddenKey{spec.Name, pkg}
+	if found, ok := g.forbids[key]; ok {
+		return found
+	}
+	var found []forbiddenPkg
+	for _, next := range g.reachable(pkg) {
+		if _, capturedVars, ok := forbids(spec, next); ok {
+			found = append(found, forbiddenPkg{next, capturedVars})
+		}
+	}
+	g.forbids[key] = found
+	return found
+}
+
+// shortestChain returns the shortest import chain from currentPkg through importedPkg to target
+// using only the hops allowed, or nil if there is none
+func (g *Graph) shortestChain(currentPkg, importedPkg, target string, allowed func(from, to string) bool) []string {
+	if !allowed(currentPkg, importedPkg) {
+		return nil
+	}
+	parent := map[string]string{importedPkg: currentPkg}
+	queue := []string{importedPkg}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == target {
+			chain := []string{node}
+			for node != currentPkg {
+				node = parent[node]
+				chain = append(chain, node)
+			}
+			slices.Reverse(chain)
+			return chain
+		}
+		for _, next := range g.imports[node] {
+			if _, ok := parent[next]; ok || next == currentPkg || !allowed(node, next) {
+				continue
+			}
+			parent[next] = node
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
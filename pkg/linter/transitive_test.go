@@ -0,0 +1,78 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestCheckTransitive(t *testing.T) {
+	t.Parallel()
+	graph := NewGraph(map[string][]string{
+		"domain":               {"usecase", "domain/model"},
+		"usecase":              {"infrastructure/db", "infrastructure/cache"},
+		"infrastructure/db":    {"database/sql"},
+		"infrastructure/cache": {"database/sql"},
+		"domain/model":         {"fmt"},
+	}, nil)
+	spec := config.Spec{
+		Name:     "no-sql",
+		Severity: config.SeverityError,
+		Rules:    config.Rules{Forbid: []string{"database/sql"}, Transitive: true},
+	}
+
+	got := CheckTransitive(spec, graph, "domain", "usecase")
+	testutil.Equals(t, got, &Violation{
+		Rule:     "no-sql",
+		Package:  "domain",
+		Import:   "usecase",
+		Severity: config.SeverityError,
+		Reason:   `depends on "database/sql" through domain -> usecase -> infrastructure/db -> database/sql`,
+		Chain:    []string{"domain", "usecase", "infrastructure/db", "database/sql"},
+	})
+	testutil.Equals(t, CheckTransitive(spec, graph, "domain", "domain/model"), (*Violation)(nil))
+
+	// Exceptions apply to each hop, leaving the chain through the cache
+	exempt := spec
+	exempt.Rules.Exempt = []config.Exception{{Pattern: "infrastructure/db"}}
+	testutil.Equals(t, CheckTransitive(exempt, graph, "domain", "usecase").Chain,
+		[]string{"domain", "usecase", "infrastructure/cache", "database/sql"})
+	exempt.Rules.Exempt = []config.Exception{{Pattern: "usecase"}}
+	testutil.Equals(t, CheckTransitive(exempt, graph, "domain", "usecase"), (*Violation)(nil))
+
+	except := spec
+	except.Rules.Except = []config.Exception{{Pattern: "infrastructure/db"}}
+	testutil.Equals(t, CheckTransitive(except, graph, "domain", "usecase").Chain,
+		[]string{"domain", "usecase", "infrastructure/cache", "database/sql"})
+	except.Rules.Except = []config.Exception{{Pattern: "infrastructure/*"}}
+	testutil.Equals(t, CheckTransitive(except, graph, "domain", "usecase"), (*Violation)(nil))
+
+	direct := spec
+	direct.Rules.Transitive = false
+	testutil.Equals(t, CheckTransitive(direct, graph, "domain", "usecase"), (*Violation)(nil))
+}
+
+func TestGraph_Memoizes(t *testing.T) {
+	t.Parallel()
+	imports := map[string][]string{
+		"usecase":           {"infrastructure/db"},
+		"infrastructure/db": {"database/sql"},
+	}
+	graph := NewGraph(imports, nil)
+	spec := config.Spec{Name: "no-sql", Rules: config.Rules{Forbid: []string{"database/sql"}, Transitive: true}}
+	forbidden := func() []string {
+		var pkgs []string
+		for _, f := range graph.forbidden(spec, "usecase") {
+			pkgs = append(pkgs, f.pkg)
+		}
+		return pkgs
+	}
+	testutil.Equals(t, forbidden(), []string{"database/sql"})
+
+	// Reachability is computed once per package and forbidden packages once per spec and package
+	imports["usecase"] = nil
+	testutil.Equals(t, graph.reachable("usecase"), []string{"infrastructure/db", "database/sql"})
+	testutil.Equals(t, forbidden(), []string{"database/sql"})
+	testutil.Equals(t, CheckTransitive(spec, graph, "domain", "infrastructure/db").Chain, []string{"domain", "infrastructure/db", "database/sql"})
+}
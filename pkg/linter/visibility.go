@@ -0,0 +1,32 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+)
+
+// CheckVisibility evaluates whether importedPkg is visible to currentPkg.
+// Packages within the restricted set may always import each other.
+// Returns a *Violation if not visible, nil otherwise.
+func CheckVisibility(visibility config.Visibility, currentPkg, importedPkg string) *Violation {
+	if !selects(visibility.Packages, importedPkg) || selects(visibility.Packages, currentPkg) {
+		return nil
+	}
+	if matchesAny(visibility.VisibleTo, currentPkg) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("%q is not visible outside its packages", importedPkg)
+	if len(visibility.VisibleTo) > 0 {
+		reason = fmt.Sprintf("%q is only visible to %s", importedPkg, strings.Join(visibility.VisibleTo, ", "))
+	}
+	return &Violation{
+		Rule:     visibility.Name,
+		Package:  currentPkg,
+		Import:   importedPkg,
+		Severity: visibility.Severity,
+		Reason:   reason,
+	}
+}
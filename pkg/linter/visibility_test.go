@@ -0,0 +1,38 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestCheckVisibility(t *testing.T) {
+	t.Parallel()
+	visibility := config.Visibility{
+		Name:      "infrastructure",
+		Severity:  config.SeverityError,
+		Packages:  config.Packages{Include: []string{"infrastructure/**"}, Exclude: []string{"infrastructure/public"}},
+		VisibleTo: []string{"cmd/**", "wiring/**"},
+	}
+
+	checkImports(t, "infrastructure", func(from, to string) *Violation { return CheckVisibility(visibility, from, to) }, []importCase{
+		{name: "visible", from: "cmd/server", to: "infrastructure/db"},
+		{name: "visible to nested package", from: "wiring/internal/di", to: "infrastructure/db/internal/pool"},
+		{name: "within packages", from: "infrastructure/db", to: "infrastructure/config"},
+		{name: "within packages through internal", from: "infrastructure/cache", to: "infrastructure/db/internal/pool"},
+		{name: "excluded package", from: "domain", to: "infrastructure/public"},
+		{name: "unrestricted package", from: "domain", to: "usecase"},
+		{name: "not visible", from: "domain", to: "infrastructure/db",
+			reason: `"infrastructure/db" is only visible to cmd/**, wiring/**`},
+		{name: "prefix of packages", from: "infrastructure-tools", to: "infrastructure/db",
+			reason: `"infrastructure/db" is only visible to cmd/**, wiring/**`},
+		{name: "from excluded package", from: "infrastructure/public", to: "infrastructure/db",
+			reason: `"infrastructure/db" is only visible to cmd/**, wiring/**`},
+	})
+
+	private := visibility
+	private.VisibleTo = nil
+	testutil.Equals(t, CheckVisibility(private, "cmd/server", "infrastructure/db").Reason,
+		`"infrastructure/db" is not visible outside its packages`)
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"encoding/xml"
+	"go/token"
+	"io"
+	"slices"
+	"strings"
+)
+
+type checkstyleDoc struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// WriteCheckstyle renders the report as Checkstyle XML, grouping findings by file.
+func WriteCheckstyle(w io.Writer, r *Report) error {
+	files := make(map[string]*checkstyleFile)
+	add := func(pos token.Position, e checkstyleError) {
+		f, ok := files[pos.Filename]
+		if !ok {
+			f = &checkstyleFile{Name: pos.Filename}
+			files[pos.Filename] = f
+		}
+		e.Line, e.Column = pos.Line, pos.Column
+		f.Errors = append(f.Errors, e)
+	}
+
+	for _, v := range r.Violations {
+		add(v.Position, checkstyleError{
+			Severity: v.Severity.String(),
+			Message:  v.Message(),
+			Source:   "arch-lint." + v.Rule,
+		})
+	}
+	for _, issue := range r.Issues {
+		add(issue.Position, checkstyleError{
+			Severity: issue.Severity.String(),
+			Message:  issue.Message,
+			Source:   "arch-lint",
+		})
+	}
+
+	doc := checkstyleDoc{Version: "4.3"}
+	for _, f := range files {
+		doc.Files = append(doc.Files, *f)
+	}
+	slices.SortFunc(doc.Files, func(a, b checkstyleFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return writeXML(w, doc)
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONVersion is the version of the JSON document layout.
+// It is incremented whenever a field is removed or changes meaning.
+const JSONVersion = 1
+
+// JSONDocument is the document written by WriteJSON.
+type JSONDocument struct {
+	Version    int             `json:"version"`
+	Violations []JSONViolation `json:"violations"`
+	Issues     []JSONIssue     `json:"issues"`
+	Fixed      []JSONEntry     `json:"fixed"`
+	Summary    Summary         `json:"summary"`
+}
+
+// JSONViolation is a single violation, located at the offending import.
+type JSONViolation struct {
+	Spec        string   `json:"spec"`
+	Severity    string   `json:"severity"`
+	Importer    string   `json:"importer"`
+	Imported    string   `json:"imported"`
+	Symbol      string   `json:"symbol,omitempty"`
+	Declaration string   `json:"declaration,omitempty"`
+	Reason      string   `json:"reason,omitempty"`
+	Chain       []string `json:"chain,omitempty"`
+	File        string   `json:"file,omitempty"`
+	Line        int      `json:"line,omitempty"`
+	Column      int      `json:"column,omitempty"`
+}
+
+// JSONIssue is a problem that is not a rule violation.
+type JSONIssue struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// JSONEntry is a baseline entry that no longer occurs.
+type JSONEntry struct {
+	Spec        string `json:"spec"`
+	Importer    string `json:"importer"`
+	Imported    string `json:"imported"`
+	Symbol      string `json:"symbol,omitempty"`
+	Declaration string `json:"declaration,omitempty"`
+}
+
+// WriteJSON renders the report as an indented JSONDocument.
+func WriteJSON(w io.Writer, r *Report) error {
+	doc := JSONDocument{
+		Version:    JSONVersion,
+		Violations: []JSONViolation{},
+		Issues:     []JSONIssue{},
+		Fixed:      []JSONEntry{},
+		Summary:    r.Summary(),
+	}
+	for _, v := range r.Violations {
+		doc.Violations = append(doc.Violations, JSONViolation{
+			Spec:        v.Rule,
+			Severity:    v.Severity.String(),
+			Importer:    v.Package,
+			Imported:    v.Import,
+			Symbol:      v.Symbol,
+			Declaration: v.Declaration,
+			Reason:      v.Reason,
+			Chain:       v.Chain,
+			File:        v.Position.Filename,
+			Line:        v.Position.Line,
+			Column:      v.Position.Column,
+		})
+	}
+	for _, issue := range r.Issues {
+		doc.Issues = append(doc.Issues, JSONIssue{
+			Severity: issue.Severity.String(),
+			Message:  issue.Message,
+			File:     issue.Position.Filename,
+			Line:     issue.Position.Line,
+			Column:   issue.Position.Column,
+		})
+	}
+	for _, e := range r.Fixed {
+		doc.Fixed = append(doc.Fixed, JSONEntry{Spec: e.Rule, Importer: e.Package, Imported: e.Import, Symbol: e.Symbol, Declaration: e.Declaration})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
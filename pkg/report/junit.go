@@ -0,0 +1,128 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/TheFellow/arch-lint/pkg/linter"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (s *junitTestSuite) add(c junitTestCase) {
+	s.Tests++
+	if c.Failure != nil {
+		s.Failures++
+	}
+	s.Cases = append(s.Cases, c)
+}
+
+// WriteJUnit renders the report as JUnit XML.
+// Each rule is a test suite with a failing test case per violation,
+// and a passing test case per package satisfying the rule.
+func WriteJUnit(w io.Writer, r *Report) error {
+	doc := junitTestSuites{Name: "arch-lint"}
+	for _, rule := range r.Rules {
+		suite := junitTestSuite{Name: rule.Name}
+		var failing []string
+		for _, v := range r.Violations {
+			if v.Rule != rule.Name {
+				continue
+			}
+			failing = append(failing, v.Package)
+			suite.add(junitTestCase{
+				Name:      junitName(v),
+				ClassName: rule.Name,
+				File:      v.Position.Filename,
+				Line:      v.Position.Line,
+				Failure: &junitFailure{
+					Type:    v.Severity.String(),
+					Message: v.Message(),
+					Text:    v.Position.String(),
+				},
+			})
+		}
+		for _, pkg := range r.Checked[rule.Name] {
+			if !slices.Contains(failing, pkg) {
+				suite.add(junitTestCase{Name: pkg, ClassName: rule.Name})
+			}
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if len(r.Issues) > 0 {
+		suite := junitTestSuite{Name: "arch-lint"}
+		for _, issue := range r.Issues {
+			suite.add(junitTestCase{
+				Name:      issue.Message,
+				ClassName: "arch-lint",
+				File:      issue.Position.Filename,
+				Line:      issue.Position.Line,
+				Failure:   &junitFailure{Type: issue.Severity.String(), Message: issue.Message, Text: issue.Position.String()},
+			})
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	for _, suite := range doc.Suites {
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+	}
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitName names the test case of a violation
+func junitName(v linter.Violation) string {
+	if v.Import == "" && v.Symbol != "" {
+		return fmt.Sprintf("%s: %s", v.Package, v.Symbol)
+	}
+	if v.Import == "" {
+		return v.Package
+	}
+	if v.Symbol != "" {
+		return fmt.Sprintf("%s uses %s", v.Package, v.Symbol)
+	}
+	return fmt.Sprintf("%s imports %s", v.Package, v.Import)
+}
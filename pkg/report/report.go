@@ -0,0 +1,105 @@
+package report
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/baseline"
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+)
+
+// Report is the outcome of an arch-lint run, ready to be rendered.
+type Report struct {
+	// Specs lists the specs of the configuration
+	Specs []config.Spec
+	// Rules lists every configured rule, including the specs
+	Rules []linter.Rule
+	// Violations lists the violations not recorded in the baseline, sorted
+	Violations []linter.Violation
+	// Issues lists problems that are not rule violations, such as unused suppressions
+	Issues []linter.Issue
+	// Fixed lists the baseline entries that no longer occur
+	Fixed []baseline.Entry
+	// Baselined counts the violations recorded in the baseline
+	Baselined int
+	// Packages lists every package scanned
+	Packages []string
+	// Checked lists the packages each rule applies to, keyed by rule name
+	Checked map[string][]string
+	// FailOn is the lowest severity that fails the run
+	FailOn config.Severity
+}
+
+// New creates a report from a lint result, filtering out the violations recorded in known.
+func New(cfg *config.Config, result *linter.Result, known *baseline.Baseline, failOn config.Severity) *Report {
+	violations, fixed := known.Filter(result.Violations)
+	slices.SortFunc(violations, func(a, b linter.Violation) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return &Report{
+		Specs:      cfg.Specs,
+		Rules:      linter.Rules(cfg),
+		Violations: violations,
+		Issues:     result.Issues,
+		Fixed:      fixed,
+		Baselined:  len(result.Violations) - len(violations),
+		Packages:   result.Packages,
+		Checked:    result.Checked,
+		FailOn:     failOn,
+	}
+}
+
+// Fails reports whether v is at or above the failure threshold.
+func (r *Report) Fails(v linter.Violation) bool {
+	return v.Severity.Rank() >= r.FailOn.Rank()
+}
+
+// FailsIssue reports whether issue is at or above the failure threshold.
+func (r *Report) FailsIssue(issue linter.Issue) bool {
+	return issue.Severity.Rank() >= r.FailOn.Rank()
+}
+
+// Failed reports whether the run failed.
+func (r *Report) Failed() bool {
+	return slices.ContainsFunc(r.Issues, r.FailsIssue) || slices.ContainsFunc(r.Violations, r.Fails)
+}
+
+// Count returns the number of violations with the given severity.
+func (r *Report) Count(severity config.Severity) int {
+	n := 0
+	for _, v := range r.Violations {
+		if v.Severity.Rank() == severity.Rank() {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary counts the findings of a run.
+type Summary struct {
+	Packages   int  `json:"packages"`
+	Violations int  `json:"violations"`
+	Errors     int  `json:"errors"`
+	Warnings   int  `json:"warnings"`
+	Infos      int  `json:"infos"`
+	Issues     int  `json:"issues"`
+	Baselined  int  `json:"baselined"`
+	Fixed      int  `json:"fixed"`
+	Failed     bool `json:"failed"`
+}
+
+// Summary counts the findings of the report.
+func (r *Report) Summary() Summary {
+	return Summary{
+		Packages:   len(r.Packages),
+		Violations: len(r.Violations),
+		Errors:     r.Count(config.SeverityError),
+		Warnings:   r.Count(config.SeverityWarning),
+		Infos:      r.Count(config.SeverityInfo),
+		Issues:     len(r.Issues),
+		Baselined:  r.Baselined,
+		Fixed:      len(r.Fixed),
+		Failed:     r.Failed(),
+	}
+}
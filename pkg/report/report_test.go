@@ -0,0 +1,74 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/baseline"
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+var (
+	cfg = &config.Config{Specs: []config.Spec{
+		{Name: "r", Description: "no b from a", Severity: config.SeverityError},
+		{Name: "w", Severity: config.SeverityWarning},
+	}}
+	known = linter.Violation{Rule: "r", Package: "a", Import: "b", Severity: config.SeverityError}
+	warn  = linter.Violation{Rule: "w", Package: "a", Import: "c", Severity: config.SeverityWarning,
+		Position: token.Position{Filename: "a/a.go", Line: 3, Column: 8}}
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	result := &linter.Result{Violations: []linter.Violation{warn, known}, Packages: []string{"a", "b"}}
+	fixed := linter.Violation{Rule: "r", Package: "a", Import: "d"}
+
+	r := New(cfg, result, baseline.New([]linter.Violation{known, fixed}), config.SeverityError)
+	testutil.Equals(t, r.Violations, []linter.Violation{warn})
+	testutil.Equals(t, r.Fixed, []baseline.Entry{{Rule: "r", Package: "a", Import: "d"}})
+	testutil.Equals(t, r.Baselined, 1)
+	testutil.Equals(t, r.Failed(), false)
+
+	r = New(cfg, result, nil, config.SeverityWarning)
+	testutil.Equals(t, len(r.Violations), 2)
+	testutil.Equals(t, r.Failed(), true)
+}
+
+func TestReport_FailedIssues(t *testing.T) {
+	t.Parallel()
+	unused := linter.Issue{Severity: config.SeverityWarning, Message: "unused suppression of [w]"}
+	expired := linter.Issue{Severity: config.SeverityError, Message: "[r] exception expired"}
+
+	r := New(cfg, &linter.Result{Issues: []linter.Issue{unused}}, nil, config.SeverityError)
+	testutil.Equals(t, r.Failed(), false)
+
+	r = New(cfg, &linter.Result{Issues: []linter.Issue{unused}}, nil, config.SeverityWarning)
+	testutil.Equals(t, r.Failed(), true)
+
+	r = New(cfg, &linter.Result{Issues: []linter.Issue{unused, expired}}, nil, config.SeverityError)
+	testutil.Equals(t, r.Failed(), true)
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+	r := New(cfg, &linter.Result{Violations: []linter.Violation{warn}, Packages: []string{"a"}}, nil, config.SeverityError)
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteJSON(&buf, r), nil)
+
+	var got JSONDocument
+	testutil.Equals(t, json.Unmarshal(buf.Bytes(), &got), nil)
+	testutil.Equals(t, got, JSONDocument{
+		Version: JSONVersion,
+		Violations: []JSONViolation{{
+			Spec: "w", Severity: "warning", Importer: "a", Imported: "c",
+			File: "a/a.go", Line: 3, Column: 8,
+		}},
+		Issues:  []JSONIssue{},
+		Fixed:   []JSONEntry{},
+		Summary: Summary{Packages: 1, Violations: 1, Warnings: 1},
+	})
+}
@@ -0,0 +1,225 @@
+package report
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/token"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifFingerprint names the fingerprint identifying a violation across runs
+	sarifFingerprint = "archLintViolation/v1"
+	// sarifIssueRule is the rule of the results reporting issues, which belong to no configured rule
+	sarifIssueRule = "arch-lint/config"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	Name                 string       `json:"name"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId,omitempty"`
+	RuleIndex           *int              `json:"ruleIndex,omitempty"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF renders the report as a SARIF 2.1.0 log.
+// Each configured rule is a SARIF rule and each violation a result located at the offending import.
+// Issues are results of the synthetic rule arch-lint/config.
+func WriteSARIF(w io.Writer, r *Report) error {
+	driver := sarifDriver{
+		Name:           "arch-lint",
+		InformationURI: "https://github.com/TheFellow/arch-lint",
+		Rules:          []sarifRule{},
+	}
+	ruleIndex := make(map[string]int)
+	for _, rule := range r.Rules {
+		if _, ok := ruleIndex[rule.Name]; ok {
+			continue
+		}
+		ruleIndex[rule.Name] = len(driver.Rules)
+		sr := sarifRule{
+			ID:               rule.Name,
+			Name:             rule.Name,
+			ShortDescription: sarifMessage{Text: rule.Description},
+		}
+		if sr.ShortDescription.Text == "" {
+			sr.ShortDescription.Text = rule.Name
+		}
+		sr.DefaultConfiguration.Level = sarifLevel(rule.Severity)
+		driver.Rules = append(driver.Rules, sr)
+	}
+
+	results := []sarifResult{}
+	occurrences := occurrences(r.Violations)
+	for n, v := range r.Violations {
+		result := sarifResult{
+			RuleID:    v.Rule,
+			Level:     sarifLevel(v.Severity),
+			Message:   sarifMessage{Text: v.Message()},
+			Locations: sarifLocations(v.Position),
+			PartialFingerprints: map[string]string{
+				sarifFingerprint: fingerprint(v, occurrences[n]),
+			},
+		}
+		if i, ok := ruleIndex[v.Rule]; ok {
+			result.RuleIndex = &i
+		}
+		results = append(results, result)
+	}
+	if len(r.Issues) > 0 {
+		sr := sarifRule{
+			ID:               sarifIssueRule,
+			Name:             sarifIssueRule,
+			ShortDescription: sarifMessage{Text: "problems with the configuration or suppressions"},
+		}
+		sr.DefaultConfiguration.Level = sarifLevel(config.SeverityWarning)
+		ruleIndex[sarifIssueRule] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sr)
+	}
+	for _, issue := range r.Issues {
+		i := ruleIndex[sarifIssueRule]
+		results = append(results, sarifResult{
+			RuleID:    sarifIssueRule,
+			RuleIndex: &i,
+			Level:     sarifLevel(issue.Severity),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: sarifLocations(issue.Position),
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func sarifLevel(severity config.Severity) string {
+	switch severity {
+	case config.SeverityInfo:
+		return "note"
+	case config.SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+func sarifLocations(pos token.Position) []sarifLocation {
+	if pos.Filename == "" {
+		return nil
+	}
+	loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(pos.Filename), URIBaseID: "%SRCROOT%"},
+	}}
+	if pos.Line > 0 {
+		loc.PhysicalLocation.Region = &sarifRegion{StartLine: pos.Line, StartColumn: pos.Column}
+	}
+	return []sarifLocation{loc}
+}
+
+// fingerprint identifies a violation independently of its position,
+// so that it is tracked across runs while the code around it changes.
+// occurrence tells apart the violations sharing everything but their position, such as two go statements of a function.
+func fingerprint(v linter.Violation, occurrence int) string {
+	key := v.Rule + "\x00" + v.Package + "\x00" + v.Import
+	if v.Symbol != "" {
+		key += "\x00" + v.Symbol
+	}
+	if v.Declaration != "" {
+		key += "\x00" + v.Declaration
+	}
+	if occurrence > 0 {
+		key += "\x00" + strconv.Itoa(occurrence)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// occurrences numbers each violation among those sharing its fingerprint, in the order of their positions
+func occurrences(violations []linter.Violation) []int {
+	byKey := make(map[string][]int)
+	for n, v := range violations {
+		key := fingerprint(v, 0)
+		byKey[key] = append(byKey[key], n)
+	}
+	occurrences := make([]int, len(violations))
+	for _, ns := range byKey {
+		slices.SortStableFunc(ns, func(a, b int) int {
+			x, y := violations[a].Position, violations[b].Position
+			return cmp.Or(strings.Compare(x.Filename, y.Filename), cmp.Compare(x.Line, y.Line), cmp.Compare(x.Column, y.Column))
+		})
+		for i, n := range ns {
+			occurrences[n] = i
+		}
+	}
+	return occurrences
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+	r := New(cfg, &linter.Result{Violations: []linter.Violation{warn}}, nil, config.SeverityError)
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteSARIF(&buf, r), nil)
+
+	var got sarifLog
+	testutil.Equals(t, json.Unmarshal(buf.Bytes(), &got), nil)
+	testutil.Equals(t, got.Version, "2.1.0")
+	run := got.Runs[0]
+	testutil.Equals(t, len(run.Tool.Driver.Rules), 2)
+	testutil.Equals(t, run.Tool.Driver.Rules[0].ShortDescription.Text, "no b from a")
+	testutil.Equals(t, run.Tool.Driver.Rules[1].ShortDescription.Text, "w")
+
+	testutil.Equals(t, len(run.Results), 1)
+	result := run.Results[0]
+	testutil.Equals(t, result.RuleID, "w")
+	testutil.Equals(t, *result.RuleIndex, 1)
+	testutil.Equals(t, result.Level, "warning")
+	testutil.Equals(t, result.Locations[0].PhysicalLocation.ArtifactLocation.URI, "a/a.go")
+	testutil.Equals(t, *result.Locations[0].PhysicalLocation.Region, sarifRegion{StartLine: 3, StartColumn: 8})
+
+	moved := warn
+	moved.Position.Line = 10
+	testutil.Equals(t, result.PartialFingerprints[sarifFingerprint], fingerprint(moved, 0))
+
+	// Declarations leaking the same type are distinct results
+	field := linter.Violation{Rule: "w", Package: "a", Import: "db", Symbol: "db.Repository", Declaration: "field Controller.Repo"}
+	newFunc := field
+	newFunc.Declaration = "func New"
+	testutil.ErrorIf(t, fingerprint(field, 0) == fingerprint(newFunc, 0), "got equal fingerprints for %q and %q", field.Declaration, newFunc.Declaration)
+}
+
+func TestWriteSARIF_Occurrences(t *testing.T) {
+	t.Parallel()
+	// Two go statements of a function only differ by their position
+	first := linter.Violation{Rule: "w", Package: "a", Symbol: "go Publish", Severity: config.SeverityWarning, Position: token.Position{Filename: "a/a.go", Line: 5, Column: 2}}
+	second := first
+	second.Position.Line = 8
+	r := New(cfg, &linter.Result{Violations: []linter.Violation{second, first}}, nil, config.SeverityError)
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteSARIF(&buf, r), nil)
+
+	var got sarifLog
+	testutil.Equals(t, json.Unmarshal(buf.Bytes(), &got), nil)
+	fingerprints := make(map[int]string)
+	for _, result := range got.Runs[0].Results {
+		fingerprints[result.Locations[0].PhysicalLocation.Region.StartLine] = result.PartialFingerprints[sarifFingerprint]
+	}
+	testutil.Equals(t, fingerprints, map[int]string{5: fingerprint(first, 0), 8: fingerprint(second, 1)})
+}
+
+func TestWriteSARIF_Issues(t *testing.T) {
+	t.Parallel()
+	issue := linter.Issue{Severity: config.SeverityWarning, Message: "unused suppression of [w]"}
+	r := New(cfg, &linter.Result{Issues: []linter.Issue{issue}}, nil, config.SeverityError)
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteSARIF(&buf, r), nil)
+
+	var got sarifLog
+	testutil.Equals(t, json.Unmarshal(buf.Bytes(), &got), nil)
+	run := got.Runs[0]
+	testutil.Equals(t, len(run.Tool.Driver.Rules), 3)
+	testutil.Equals(t, run.Tool.Driver.Rules[2].ID, "arch-lint/config")
+
+	testutil.Equals(t, len(run.Results), 1)
+	result := run.Results[0]
+	testutil.Equals(t, result.RuleID, "arch-lint/config")
+	testutil.Equals(t, *result.RuleIndex, 2)
+	testutil.Equals(t, result.Level, "warning")
+}
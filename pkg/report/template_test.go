@@ -0,0 +1,27 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestWriteTemplate(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "csv.tmpl")
+	text := `{{ range .Violations }}{{ .Rule }},{{ .Package }},{{ .Import }},{{ .Position.Line }}
+{{ end }}{{ .Summary.Violations }}/{{ len .Specs }} {{ json .Packages }}`
+	testutil.Equals(t, os.WriteFile(path, []byte(text), 0o644), nil)
+
+	tmpl, err := ParseTemplate(path)
+	testutil.Equals(t, err, nil)
+	r := New(cfg, &linter.Result{Violations: []linter.Violation{warn}, Packages: []string{"a"}}, nil, config.SeverityError)
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteTemplate(&buf, r, tmpl), nil)
+	testutil.Equals(t, buf.String(), "w,a,c,3\n1/2 [\"a\"]")
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteText renders the report as plain text, one line per finding.
+func WriteText(w io.Writer, r *Report) error {
+	for _, e := range r.Fixed {
+		fmt.Fprintf(w, "arch-lint: baseline entry fixed: %s\n", e)
+	}
+	for _, issue := range r.Issues {
+		fmt.Fprintln(w, issue)
+	}
+	for _, v := range r.Violations {
+		fmt.Fprintln(w, v)
+	}
+
+	switch {
+	case r.Failed():
+	case len(r.Violations) > 0 || len(r.Issues) > 0:
+		fmt.Fprintf(w, "✔ arch-lint: no violations at or above severity %s.\n", r.FailOn)
+	default:
+		fmt.Fprintln(w, "✔ arch-lint: no forbidden imports found.")
+	}
+	return nil
+}
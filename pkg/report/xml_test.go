@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/TheFellow/arch-lint/pkg/config"
+	"github.com/TheFellow/arch-lint/pkg/linter"
+	"github.com/TheFellow/arch-lint/pkg/testutil"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+	result := &linter.Result{
+		Violations: []linter.Violation{warn},
+		Checked:    map[string][]string{"r": {"a", "b"}, "w": {"a", "b"}},
+	}
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteJUnit(&buf, New(cfg, result, nil, config.SeverityError)), nil)
+
+	var got junitTestSuites
+	testutil.Equals(t, xml.Unmarshal(buf.Bytes(), &got), nil)
+	testutil.Equals(t, got.Tests, 4)
+	testutil.Equals(t, got.Failures, 1)
+	testutil.Equals(t, len(got.Suites), 2)
+
+	passing := got.Suites[0]
+	testutil.Equals(t, passing.Name, "r")
+	testutil.Equals(t, passing.Failures, 0)
+	testutil.Equals(t, passing.Cases[0].Name, "a")
+	testutil.Equals(t, passing.Cases[1].Name, "b")
+
+	failing := got.Suites[1]
+	testutil.Equals(t, failing.Name, "w")
+	testutil.Equals(t, failing.Tests, 2)
+	testutil.Equals(t, failing.Cases[0].Name, "a imports c")
+	testutil.Equals(t, failing.Cases[0].Failure.Type, "warning")
+	testutil.Equals(t, failing.Cases[1].Name, "b")
+	testutil.Equals(t, failing.Cases[1].Failure, (*junitFailure)(nil))
+}
+
+func TestWriteCheckstyle(t *testing.T) {
+	t.Parallel()
+	other := linter.Violation{Rule: "r", Package: "b", Import: "c", Position: known.Position}
+	other.Position.Filename = "b/b.go"
+	result := &linter.Result{Violations: []linter.Violation{other, warn, warn}}
+	var buf bytes.Buffer
+	testutil.Equals(t, WriteCheckstyle(&buf, New(cfg, result, nil, config.SeverityError)), nil)
+
+	var got checkstyleDoc
+	testutil.Equals(t, xml.Unmarshal(buf.Bytes(), &got), nil)
+	testutil.Equals(t, len(got.Files), 2)
+	testutil.Equals(t, got.Files[0].Name, "a/a.go")
+	testutil.Equals(t, len(got.Files[0].Errors), 2)
+	testutil.Equals(t, got.Files[0].Errors[0], checkstyleError{
+		Line: 3, Column: 8, Severity: "warning",
+		Message: `[w] package "a" imports "c"`, Source: "arch-lint.w",
+	})
+	testutil.Equals(t, got.Files[1].Name, "b/b.go")
+}
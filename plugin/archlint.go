@@ -1,6 +1,8 @@
 package archlint
 
 import (
+	"os"
+
 	"golang.org/x/tools/go/analysis"
 
 	archlint "github.com/TheFellow/arch-lint/pkg/analysis"
@@ -19,6 +21,11 @@ func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
 	return []*analysis.Analyzer{archlint.Analyzer}, nil
 }
 
+// GetLoadMode requests type information only when the configuration has symbol rules,
+// as syntax is enough for import rules.
 func (p *Plugin) GetLoadMode() string {
+	if dir, err := os.Getwd(); err == nil && archlint.NeedsTypesInfo(dir) {
+		return "typesinfo"
+	}
 	return "syntax"
 }